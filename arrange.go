@@ -0,0 +1,160 @@
+package rekt
+
+// Alignment defines where a set is placed across the direction it is being arranged in
+type Alignment uint8
+
+const (
+	// AlignStart aligns sets to the top of a row or the left of a column
+	AlignStart Alignment = iota
+	// AlignCentre centres sets within a row or column
+	AlignCentre
+	// AlignEnd aligns sets to the bottom of a row or the right of a column
+	AlignEnd
+)
+
+const (
+	AlignTop    = AlignStart
+	AlignBottom = AlignEnd
+	AlignLeft   = AlignStart
+	AlignRight  = AlignEnd
+)
+
+// ArrangeRow places the sets left to right starting at the world space origin
+//
+// sets are placed by the bounding box of their content (see WorldBounds) so each set will touch
+// the one before it, across the row they are aligned against the tallest set
+// sets without any content are left where they are
+func ArrangeRow[T comparable](sets []*Set[T], origin Point, align Alignment) {
+	arrangeLine(sets, origin, align, false)
+}
+
+// ArrangeColumn places the sets top to bottom starting at the world space origin
+//
+// sets are placed by the bounding box of their content (see WorldBounds) so each set will touch
+// the one before it, across the column they are aligned against the widest set
+// sets without any content are left where they are
+func ArrangeColumn[T comparable](sets []*Set[T], origin Point, align Alignment) {
+	arrangeLine(sets, origin, align, true)
+}
+
+// ArrangeGrid places the sets in rows of the given number of columns starting at the world
+// space origin
+//
+// each row is arranged in the same way as ArrangeRow and then dropped until it rests on the
+// content placed above it, each set of the row ends up below everything above it that it shares
+// some width with and at least one of them touches the content above, this keeps every row
+// reachable from the one before it even when the sets in a row have different heights
+// sets without any content are left where they are and do not take up a space in the grid
+func ArrangeGrid[T comparable](sets []*Set[T], origin Point, columns int, align Alignment) {
+	if columns < 1 {
+		columns = 1
+	}
+
+	var placeable []*Set[T]
+	for _, set := range sets {
+		if _, ok := set.WorldBounds(); ok {
+			placeable = append(placeable, set)
+		}
+	}
+
+	var placed []Rectangle[T]
+	for start := 0; start < len(placeable); start += columns {
+		row := placeable[start:min(start+columns, len(placeable))]
+
+		ArrangeRow(row, origin, align)
+
+		if drop, ok := restingDrop(row, placed); ok {
+			for _, set := range row {
+				set.Move(set.X, set.Y+drop)
+			}
+		}
+
+		for _, set := range row {
+			bounds, _ := set.WorldBounds()
+			placed = append(placed, bounds)
+		}
+	}
+}
+
+// restingDrop finds how far the row needs to move down so that none of its sets overlap the
+// placed content that they share some width with, with at least one of them touching it
+// false will be returned if no set in the row shares any width with the placed content
+func restingDrop[T comparable](row []*Set[T], placed []Rectangle[T]) (int, bool) {
+	var (
+		drop  int
+		found bool
+	)
+
+	for _, set := range row {
+		bounds, _ := set.WorldBounds()
+
+		for _, above := range placed {
+			if !sharesSpan(bounds, above, Bottom) {
+				continue
+			}
+
+			if needed := above.Z - bounds.Y; !found || needed > drop {
+				drop, found = needed, true
+			}
+		}
+	}
+
+	return drop, found
+}
+
+// arrangeLine places the sets one after another along a row (or column if vertical is set)
+func arrangeLine[T comparable](sets []*Set[T], origin Point, align Alignment, vertical bool) {
+	across := lineSize(sets, vertical)
+	along := origin.X
+	if vertical {
+		along = origin.Y
+	}
+
+	for _, set := range sets {
+		bounds, ok := set.WorldBounds()
+		if !ok {
+			continue
+		}
+
+		length, size := bounds.Width(), bounds.Height()
+		if vertical {
+			length, size = size, length
+		}
+
+		offset := 0
+		switch align {
+		case AlignCentre:
+			offset = (across - size) / 2
+		case AlignEnd:
+			offset = across - size
+		}
+
+		x, y := along, origin.Y+offset
+		if vertical {
+			x, y = origin.X+offset, along
+		}
+
+		set.Move(set.X+x-bounds.X, set.Y+y-bounds.Y)
+		along += length
+	}
+}
+
+// lineSize finds the largest height (or width if vertical is set) of the content of the sets
+func lineSize[T comparable](sets []*Set[T], vertical bool) int {
+	var size int
+
+	for _, set := range sets {
+		bounds, ok := set.WorldBounds()
+		if !ok {
+			continue
+		}
+
+		if vertical {
+			size = max(size, bounds.Width())
+		} else {
+			size = max(size, bounds.Height())
+		}
+	}
+
+	return size
+}
@@ -0,0 +1,192 @@
+package rekt_test
+
+import (
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+// arrangeSets builds a square set, a tall set with its content offset from its origin and a wide
+// set, each starting off somewhere random
+func arrangeSets() []*rekt.Set[string] {
+	return []*rekt.Set[string]{
+		layoutSet("square", 500, 20, rekt.NewRectangle("square-1", 0, 0, 100, 100)),
+		layoutSet("tall", 30, 700, rekt.NewRectangle("tall-1", 10, 10, 60, 210)),
+		layoutSet("wide", 0, 0, rekt.NewRectangle("wide-1", 0, 0, 200, 50)),
+	}
+}
+
+func worldBounds(t *testing.T, sets []*rekt.Set[string]) []rekt.Rectangle[string] {
+	var bounds []rekt.Rectangle[string]
+
+	for _, set := range sets {
+		rect, ok := set.WorldBounds()
+		require.True(t, ok)
+		bounds = append(bounds, rect)
+	}
+
+	return bounds
+}
+
+var arrangeRowTests = []struct {
+	name     string
+	align    rekt.Alignment
+	expected []rekt.Rectangle[string]
+}{
+	{"top", rekt.AlignTop, []rekt.Rectangle[string]{
+		rekt.NewRectangle("square", 0, 0, 100, 100),
+		rekt.NewRectangle("tall", 100, 0, 150, 200),
+		rekt.NewRectangle("wide", 150, 0, 350, 50),
+	}},
+	{"centre", rekt.AlignCentre, []rekt.Rectangle[string]{
+		rekt.NewRectangle("square", 0, 50, 100, 150),
+		rekt.NewRectangle("tall", 100, 0, 150, 200),
+		rekt.NewRectangle("wide", 150, 75, 350, 125),
+	}},
+	{"bottom", rekt.AlignBottom, []rekt.Rectangle[string]{
+		rekt.NewRectangle("square", 0, 100, 100, 200),
+		rekt.NewRectangle("tall", 100, 0, 150, 200),
+		rekt.NewRectangle("wide", 150, 150, 350, 200),
+	}},
+}
+
+func TestArrangeRow(t *testing.T) {
+	for _, testCase := range arrangeRowTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			sets := arrangeSets()
+			rekt.ArrangeRow(sets, rekt.NewPoint(0, 0), testCase.align)
+
+			bounds := worldBounds(t, sets)
+			require.Equal(t, testCase.expected, bounds)
+			require.Contains(t, bounds[0].Touches(bounds[1]), rekt.Right)
+			require.Contains(t, bounds[1].Touches(bounds[2]), rekt.Right)
+
+			layout, err := rekt.NewLayout(sets...)
+			require.Nil(t, err)
+			require.Nil(t, layout.ValidateConnectivity())
+		})
+	}
+}
+
+var arrangeColumnTests = []struct {
+	name     string
+	align    rekt.Alignment
+	expected []rekt.Rectangle[string]
+}{
+	{"left", rekt.AlignLeft, []rekt.Rectangle[string]{
+		rekt.NewRectangle("square", 10, 10, 110, 110),
+		rekt.NewRectangle("tall", 10, 110, 60, 310),
+		rekt.NewRectangle("wide", 10, 310, 210, 360),
+	}},
+	{"centre", rekt.AlignCentre, []rekt.Rectangle[string]{
+		rekt.NewRectangle("square", 60, 10, 160, 110),
+		rekt.NewRectangle("tall", 85, 110, 135, 310),
+		rekt.NewRectangle("wide", 10, 310, 210, 360),
+	}},
+	{"right", rekt.AlignRight, []rekt.Rectangle[string]{
+		rekt.NewRectangle("square", 110, 10, 210, 110),
+		rekt.NewRectangle("tall", 160, 110, 210, 310),
+		rekt.NewRectangle("wide", 10, 310, 210, 360),
+	}},
+}
+
+func TestArrangeColumn(t *testing.T) {
+	for _, testCase := range arrangeColumnTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			sets := arrangeSets()
+			rekt.ArrangeColumn(sets, rekt.NewPoint(10, 10), testCase.align)
+
+			bounds := worldBounds(t, sets)
+			require.Equal(t, testCase.expected, bounds)
+			require.Contains(t, bounds[0].Touches(bounds[1]), rekt.Bottom)
+			require.Contains(t, bounds[1].Touches(bounds[2]), rekt.Bottom)
+		})
+	}
+}
+
+func TestArrangeGrid(t *testing.T) {
+	sets := append(arrangeSets(), layoutSet("last", 0, 0, rekt.NewRectangle("last-1", 0, 0, 100, 100)))
+	empty := layoutSet("empty", 1000, 1000)
+
+	rekt.ArrangeGrid(append([]*rekt.Set[string]{empty}, sets...), rekt.NewPoint(0, 0), 2, rekt.AlignTop)
+
+	require.Equal(t, []rekt.Rectangle[string]{
+		rekt.NewRectangle("square", 0, 0, 100, 100),
+		rekt.NewRectangle("tall", 100, 0, 150, 200),
+		rekt.NewRectangle("wide", 0, 200, 200, 250),
+		rekt.NewRectangle("last", 200, 200, 300, 300),
+	}, worldBounds(t, sets))
+
+	// the set is positioned so that its offset content lands in place
+	require.Equal(t, 90, sets[1].X)
+	require.Equal(t, -10, sets[1].Y)
+	require.Equal(t, 1000, empty.X)
+
+	layout, err := rekt.NewLayout(sets...)
+	require.Nil(t, err)
+	require.Nil(t, layout.ValidateConnectivity())
+}
+
+var arrangeGridMixedHeightsTests = []struct {
+	name     string
+	align    rekt.Alignment
+	expected []rekt.Rectangle[string]
+}{
+	// the small set rests on the short set above it rather than below the tall one
+	{"top", rekt.AlignTop, []rekt.Rectangle[string]{
+		rekt.NewRectangle("short", 0, 0, 100, 10),
+		rekt.NewRectangle("tall", 100, 0, 200, 200),
+		rekt.NewRectangle("small", 0, 10, 50, 60),
+	}},
+	{"bottom", rekt.AlignBottom, []rekt.Rectangle[string]{
+		rekt.NewRectangle("short", 0, 190, 100, 200),
+		rekt.NewRectangle("tall", 100, 0, 200, 200),
+		rekt.NewRectangle("small", 0, 200, 50, 250),
+	}},
+}
+
+func TestArrangeGridMixedHeights(t *testing.T) {
+	for _, testCase := range arrangeGridMixedHeightsTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			sets := []*rekt.Set[string]{
+				layoutSet("short", 0, 0, rekt.NewRectangle("short-1", 0, 0, 100, 10)),
+				layoutSet("tall", 0, 0, rekt.NewRectangle("tall-1", 0, 0, 100, 200)),
+				layoutSet("small", 0, 0, rekt.NewRectangle("small-1", 0, 0, 50, 50)),
+			}
+
+			rekt.ArrangeGrid(sets, rekt.NewPoint(0, 0), 2, testCase.align)
+			require.Equal(t, testCase.expected, worldBounds(t, sets))
+
+			layout, err := rekt.NewLayout(sets...)
+			require.Nil(t, err)
+			require.Nil(t, layout.ValidateConnectivity())
+		})
+	}
+}
+
+func TestArrangeGridRestsOnTallestBelow(t *testing.T) {
+	sets := []*rekt.Set[string]{
+		layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 10)),
+		layoutSet("b", 0, 0, rekt.NewRectangle("b-1", 0, 0, 100, 300)),
+		layoutSet("c", 0, 0, rekt.NewRectangle("c-1", 0, 0, 100, 10)),
+		layoutSet("d", 0, 0, rekt.NewRectangle("d-1", 0, 0, 50, 10)),
+		layoutSet("e", 0, 0, rekt.NewRectangle("e-1", 0, 0, 200, 10)),
+	}
+
+	rekt.ArrangeGrid(sets, rekt.NewPoint(0, 0), 2, rekt.AlignTop)
+
+	// rows move as a whole so the second row is held up by d resting on b, e then rests on the
+	// second row rather than the first
+	require.Equal(t, []rekt.Rectangle[string]{
+		rekt.NewRectangle("a", 0, 0, 100, 10),
+		rekt.NewRectangle("b", 100, 0, 200, 300),
+		rekt.NewRectangle("c", 0, 300, 100, 310),
+		rekt.NewRectangle("d", 100, 300, 150, 310),
+		rekt.NewRectangle("e", 0, 310, 200, 320),
+	}, worldBounds(t, sets))
+
+	layout, err := rekt.NewLayout(sets...)
+	require.Nil(t, err)
+	require.Nil(t, layout.ValidateConnectivity())
+}
@@ -0,0 +1,318 @@
+package rekt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrChildrenOverlap = errors.New("rectangle overlaps another child of the set")
+)
+
+// BatchFailure describes a single rectangle that stopped a Batch from being applied
+type BatchFailure[T any] struct {
+	// ID is the id of the offending rectangle
+	ID T
+	// Err is the reason the rectangle was rejected
+	Err error
+}
+
+// BatchError lists every rectangle that stopped a Batch from being applied
+// errors.Is can be used to match against the error of any of the failures
+type BatchError[T any] struct {
+	Failures []BatchFailure[T]
+}
+
+// Error implements error
+func (err *BatchError[T]) Error() string {
+	reasons := make([]string, 0, len(err.Failures))
+	for _, failure := range err.Failures {
+		// validation errors already name the rectangle
+		var validation *ValidationError[T]
+		if errors.As(failure.Err, &validation) {
+			reasons = append(reasons, failure.Err.Error())
+			continue
+		}
+
+		reasons = append(reasons, fmt.Sprintf("rectangle %v: %s", failure.ID, failure.Err))
+	}
+
+	return fmt.Sprintf("batch rejected with %d failure(s): %s", len(err.Failures), strings.Join(reasons, "; "))
+}
+
+// Unwrap allows errors.Is and errors.As to match against the error of each failure
+func (err *BatchError[T]) Unwrap() []error {
+	errs := make([]error, 0, len(err.Failures))
+	for _, failure := range err.Failures {
+		errs = append(errs, failure.Err)
+	}
+
+	return errs
+}
+
+var _ error = (*BatchError[int])(nil)
+
+// Batch collects changes to the children of a Set so they can be validated and applied together
+//
+// nothing is changed on the set until Apply is called, at which point either every change is
+// made or none of them are
+type Batch[T comparable] struct {
+	set *Set[T]
+	ops []batchOp[T]
+}
+
+// batchOp is a single change that has been queued on a Batch
+type batchOp[T comparable] struct {
+	kind batchOpKind
+	id   T
+	rect Rectangle[T]
+	x, y int
+}
+
+type batchOpKind uint8
+
+const (
+	batchAdd batchOpKind = iota
+	batchRemove
+	batchUpdate
+	batchMove
+)
+
+// NewBatch starts a new batch of changes for the set
+func NewBatch[T comparable](set *Set[T]) *Batch[T] {
+	return &Batch[T]{set: set}
+}
+
+// AddRectangle queues the rectangle to be added to the set
+func (batch *Batch[T]) AddRectangle(rect Rectangle[T]) *Batch[T] {
+	batch.ops = append(batch.ops, batchOp[T]{kind: batchAdd, id: rect.ID, rect: rect})
+	return batch
+}
+
+// RemoveRectangle queues the child with the given id to be removed from the set
+func (batch *Batch[T]) RemoveRectangle(id T) *Batch[T] {
+	batch.ops = append(batch.ops, batchOp[T]{kind: batchRemove, id: id})
+	return batch
+}
+
+// UpdateRectangle queues the child with the given id to be replaced with rect
+func (batch *Batch[T]) UpdateRectangle(id T, rect Rectangle[T]) *Batch[T] {
+	batch.ops = append(batch.ops, batchOp[T]{kind: batchUpdate, id: id, rect: rect})
+	return batch
+}
+
+// MoveRectangle queues the child with the given id to be moved so its top left is at x,y
+func (batch *Batch[T]) MoveRectangle(id T, x, y int) *Batch[T] {
+	batch.ops = append(batch.ops, batchOp[T]{kind: batchMove, id: id, x: x, y: y})
+	return batch
+}
+
+// Len returns the number of changes queued on the batch
+func (batch *Batch[T]) Len() int {
+	return len(batch.ops)
+}
+
+// Apply validates every queued change and applies them to the set if they are all valid
+//
+// changes are checked in the order they were queued so later changes see the result of earlier
+// ones, each change gets the same validation as the matching method on Set, once all of the
+// changes have been checked the children added, moved or updated by the batch must not overlap
+// any other child and the batch must not make connectivity worse (see ValidateConnectivity),
+// changes that failed are left out of the result when these checks are made and connectivity is
+// only checked if nothing overlaps
+//
+// overlaps between children the batch did not change are left alone, in the same way as calling
+// the matching methods on Set does not require the rest of the set to be clear of overlaps
+//
+// connectivity is compared against the children before the batch rather than required of the
+// whole set, so a set whose children are already split into groups that cannot reach each other
+// can still be changed as long as the batch does not split a group or add a rectangle that cannot
+// reach any of the existing children
+//
+// if anything fails the set is left untouched and a *BatchError listing every offending
+// rectangle is returned, the batch is emptied either way
+func (batch *Batch[T]) Apply() error {
+	ops := batch.ops
+	batch.ops = nil
+
+	children, changed, failures := batch.simulate(ops)
+
+	// overlapping children would also be reported as disconnected so connectivity is only checked
+	// once the changed children are clear of the rest
+	if overlaps := overlapFailures(children, changed); len(overlaps) > 0 {
+		failures = append(failures, overlaps...)
+	} else {
+		failures = append(failures, connectivityFailures(batch.set.children, children)...)
+	}
+
+	if len(failures) > 0 {
+		return &BatchError[T]{Failures: failures}
+	}
+
+	batch.set.children = children
+	reindexSet(batch.set)
+	resizeSetToContent(batch.set)
+
+	return nil
+}
+
+// simulate runs the changes against a copy of the children of the set
+// along with the resulting children it returns the ids of the children that were added, moved or
+// updated
+func (batch *Batch[T]) simulate(ops []batchOp[T]) ([]Rectangle[T], map[T]bool, []BatchFailure[T]) {
+	var (
+		failures []BatchFailure[T]
+		children = append([]Rectangle[T](nil), batch.set.children...)
+		changed  = make(map[T]bool)
+	)
+
+	indexOf := func(id T) int {
+		for i, child := range children {
+			if child.ID == id {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	inUse := func(id T) bool {
+		return indexOf(id) != -1 || batch.set.indexOfSet(id) != -1
+	}
+
+	// each problem with a rectangle is listed as its own failure
+	fail := func(id T, err error) {
+		for _, err := range splitErrors(err) {
+			failures = append(failures, BatchFailure[T]{ID: id, Err: err})
+		}
+	}
+
+	for _, op := range ops {
+		if op.kind == batchAdd {
+			if err := validateChild(op.rect); err != nil {
+				fail(op.id, err)
+			} else if inUse(op.id) {
+				fail(op.id, duplicateIDError(op.id))
+			} else {
+				children = append(children, op.rect)
+				changed[op.id] = true
+			}
+
+			continue
+		}
+
+		i := indexOf(op.id)
+		if i == -1 {
+			fail(op.id, ErrRectangleNotInSet)
+			continue
+		}
+
+		var rect Rectangle[T]
+		switch op.kind {
+		case batchRemove:
+			children = append(children[:i], children[i+1:]...)
+			continue
+		case batchUpdate:
+			rect = op.rect
+		case batchMove:
+			rect = children[i]
+			rect.W += op.x - rect.X
+			rect.Z += op.y - rect.Y
+			rect.X, rect.Y = op.x, op.y
+		}
+
+		if err := validateChild(rect); err != nil {
+			fail(op.id, err)
+		} else if rect.ID != op.id && inUse(rect.ID) {
+			fail(rect.ID, duplicateIDError(rect.ID))
+		} else {
+			children[i] = rect
+			changed[rect.ID] = true
+		}
+	}
+
+	return children, changed, failures
+}
+
+// overlapFailures reports every child that overlaps another where at least one of the two was
+// changed
+func overlapFailures[T comparable](children []Rectangle[T], changed map[T]bool) []BatchFailure[T] {
+	var failures []BatchFailure[T]
+
+	for i, child := range children {
+		for j, other := range children {
+			if i != j && (changed[child.ID] || changed[other.ID]) && child.Overlaps(other) {
+				failures = append(failures, BatchFailure[T]{ID: child.ID, Err: ErrChildrenOverlap})
+				break
+			}
+		}
+	}
+
+	return failures
+}
+
+// connectivityFailures reports every child that the batch has cut off from the rest of the
+// children
+//
+// each group of children that could reach each other before the batch keeps the group that holds
+// most of them afterwards, every child in a group afterwards that is not kept by any of the
+// original groups is reported, this covers children split off from their original group along
+// with new children that cannot reach any of the original ones
+// if none of the original children are left the largest group is kept
+func connectivityFailures[T comparable](before, after []Rectangle[T]) []BatchFailure[T] {
+	graph := NewGraph(&Set[T]{children: after})
+
+	components := graph.Components()
+	if len(components) < 2 {
+		return nil
+	}
+
+	previousGraph := NewGraph(&Set[T]{children: before})
+	previous := make(map[T]int, len(before))
+	for i, component := range previousGraph.Components() {
+		for _, node := range component {
+			previous[previousGraph.Nodes[node].Rectangle.ID] = i
+		}
+	}
+
+	// for each original group, the component that holds most of it and how many of it that is
+	// components are ordered largest first so ties go to the larger component
+	var (
+		keptBy    = make(map[int]int)
+		keptCount = make(map[int]int)
+	)
+
+	for i, component := range components {
+		counts := make(map[int]int)
+		for _, node := range component {
+			if group, ok := previous[graph.Nodes[node].Rectangle.ID]; ok {
+				counts[group]++
+			}
+		}
+
+		for group, count := range counts {
+			if _, ok := keptBy[group]; !ok || count > keptCount[group] {
+				keptBy[group], keptCount[group] = i, count
+			}
+		}
+	}
+
+	kept := map[int]bool{0: len(keptBy) == 0}
+	for _, i := range keptBy {
+		kept[i] = true
+	}
+
+	var failures []BatchFailure[T]
+	for i, component := range components {
+		if kept[i] {
+			continue
+		}
+
+		for _, node := range component {
+			failures = append(failures, BatchFailure[T]{ID: graph.Nodes[node].Rectangle.ID, Err: ErrDisconnected})
+		}
+	}
+
+	return failures
+}
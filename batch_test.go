@@ -0,0 +1,198 @@
+package rekt_test
+
+import (
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+func batchSet() *rekt.Set[string] {
+	return layoutSet("set", 0, 0,
+		rekt.NewRectangle("left", 0, 0, 100, 100),
+		rekt.NewRectangle("right", 100, 0, 200, 100),
+	)
+}
+
+func TestBatchApply(t *testing.T) {
+	set := batchSet()
+
+	err := rekt.NewBatch(set).
+		RemoveRectangle("left").
+		MoveRectangle("right", 0, 0).
+		AddRectangle(rekt.NewRectangle("below", 0, 100, 100, 200)).
+		UpdateRectangle("below", rekt.NewRectangle("below", 0, 100, 200, 200)).
+		Apply()
+
+	require.Nil(t, err)
+	require.Equal(t, []rekt.Rectangle[string]{
+		rekt.NewRectangle("right", 0, 0, 100, 100),
+		rekt.NewRectangle("below", 0, 100, 200, 200),
+	}, set.Children())
+	require.Equal(t, rekt.NewRectangle("set", 0, 0, 200, 200), set.Rectangle)
+	require.NotNil(t, set.Child("below"))
+	require.Nil(t, set.Child("left"))
+}
+
+var batchRejectTests = []struct {
+	name     string
+	batch    func(batch *rekt.Batch[string])
+	expected []rekt.BatchFailure[string]
+}{
+	{
+		"invalid rectangles",
+		func(batch *rekt.Batch[string]) {
+			batch.AddRectangle(rekt.NewRectangle("zero", 200, 0, 200, 100))
+			batch.AddRectangle(rekt.NewRectangle("left", 200, 0, 300, 100))
+			batch.MoveRectangle("right", -10, 0)
+			batch.RemoveRectangle("missing")
+		},
+		[]rekt.BatchFailure[string]{
+			{ID: "zero", Err: rekt.ErrZoroArea},
+			{ID: "left", Err: rekt.ErrDuplicateID},
+			{ID: "right", Err: rekt.ErrNegativePositionInSet},
+			{ID: "missing", Err: rekt.ErrRectangleNotInSet},
+		},
+	},
+	{
+		"overlap",
+		func(batch *rekt.Batch[string]) {
+			batch.MoveRectangle("right", 50, 0)
+			batch.AddRectangle(rekt.NewRectangle("extra", 50, 50, 150, 150))
+		},
+		[]rekt.BatchFailure[string]{
+			{ID: "left", Err: rekt.ErrChildrenOverlap},
+			{ID: "right", Err: rekt.ErrChildrenOverlap},
+			{ID: "extra", Err: rekt.ErrChildrenOverlap},
+		},
+	},
+	{
+		"disconnected",
+		func(batch *rekt.Batch[string]) {
+			batch.MoveRectangle("right", 200, 0)
+			batch.AddRectangle(rekt.NewRectangle("island", 0, 500, 100, 600))
+		},
+		[]rekt.BatchFailure[string]{
+			{ID: "right", Err: rekt.ErrDisconnected},
+			{ID: "island", Err: rekt.ErrDisconnected},
+		},
+	},
+	{
+		"earlier failures are left out of the result",
+		func(batch *rekt.Batch[string]) {
+			// the move fails so right is still in the way of the new rectangle
+			batch.MoveRectangle("right", 100, -1)
+			batch.AddRectangle(rekt.NewRectangle("new", 150, 0, 250, 100))
+		},
+		[]rekt.BatchFailure[string]{
+			{ID: "right", Err: rekt.ErrNegativePositionInSet},
+			{ID: "right", Err: rekt.ErrChildrenOverlap},
+			{ID: "new", Err: rekt.ErrChildrenOverlap},
+		},
+	},
+}
+
+func TestBatchApplyRejects(t *testing.T) {
+	for _, testCase := range batchRejectTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			set := batchSet()
+			rect := set.Rectangle
+			children := append([]rekt.Rectangle[string](nil), set.Children()...)
+
+			batch := rekt.NewBatch(set)
+			testCase.batch(batch)
+			err := batch.Apply()
+
+			var batchErr *rekt.BatchError[string]
+			require.ErrorAs(t, err, &batchErr)
+			require.Len(t, batchErr.Failures, len(testCase.expected))
+
+			for i, failure := range testCase.expected {
+				require.Equal(t, failure.ID, batchErr.Failures[i].ID)
+				require.ErrorIs(t, batchErr.Failures[i].Err, failure.Err)
+				require.ErrorIs(t, err, failure.Err)
+			}
+
+			require.Equal(t, rect, set.Rectangle)
+			require.Equal(t, children, set.Children())
+			require.Equal(t, 0, batch.Len())
+		})
+	}
+}
+
+func TestBatchEmpty(t *testing.T) {
+	set := batchSet()
+	children := append([]rekt.Rectangle[string](nil), set.Children()...)
+
+	require.Nil(t, rekt.NewBatch(set).Apply())
+	require.Equal(t, children, set.Children())
+}
+
+func TestBatchKeepsIndex(t *testing.T) {
+	set := batchSet()
+	set.EnableIndex(50)
+
+	require.Nil(t, rekt.NewBatch(set).
+		AddRectangle(rekt.NewRectangle("below", 0, 100, 100, 200)).
+		Apply(),
+	)
+
+	child := set.ChildAt(rekt.NewPoint(50, 150))
+	require.NotNil(t, child)
+	require.Equal(t, "below", child.ID)
+}
+
+func TestBatchDisjointSet(t *testing.T) {
+	// the children of the set can't reach each other before any batch is applied
+	set := layoutSet("set", 0, 0,
+		rekt.NewRectangle("left", 0, 0, 100, 100),
+		rekt.NewRectangle("right", 300, 0, 400, 100),
+	)
+	require.ErrorIs(t, rekt.ValidateConnectivity(set), rekt.ErrDisconnected)
+
+	// changes that do not make connectivity any worse are allowed
+	require.Nil(t, rekt.NewBatch(set).Apply())
+	require.Nil(t, rekt.NewBatch(set).MoveRectangle("right", 300, 50).Apply())
+	require.Nil(t, rekt.NewBatch(set).AddRectangle(rekt.NewRectangle("next", 100, 0, 200, 100)).Apply())
+
+	// a new rectangle that can't reach any of the existing ones is rejected
+	err := rekt.NewBatch(set).AddRectangle(rekt.NewRectangle("island", 0, 500, 100, 600)).Apply()
+
+	var batchErr *rekt.BatchError[string]
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, []rekt.BatchFailure[string]{{ID: "island", Err: rekt.ErrDisconnected}}, batchErr.Failures)
+
+	// joining the groups together is allowed but splitting them again is not
+	require.Nil(t, rekt.NewBatch(set).AddRectangle(rekt.NewRectangle("bridge", 200, 50, 300, 150)).Apply())
+	require.Nil(t, rekt.ValidateConnectivity(set))
+
+	err = rekt.NewBatch(set).RemoveRectangle("bridge").Apply()
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, []rekt.BatchFailure[string]{{ID: "right", Err: rekt.ErrDisconnected}}, batchErr.Failures)
+	require.NotNil(t, set.Child("bridge"))
+}
+
+func TestBatchExistingOverlap(t *testing.T) {
+	// the children of the set already overlap before any batch is applied
+	set := layoutSet("set", 0, 0,
+		rekt.NewRectangle("a", 0, 0, 100, 100),
+		rekt.NewRectangle("b", 50, 0, 150, 100),
+		rekt.NewRectangle("c", 150, 0, 250, 100),
+	)
+	require.True(t, set.Child("a").Overlaps(*set.Child("b")))
+
+	// changes that do not add an overlap are allowed the same as they are on the set
+	require.Nil(t, set.MoveRectangle("c", 150, 10))
+	require.Nil(t, rekt.NewBatch(set).MoveRectangle("c", 150, 20).Apply())
+	require.Equal(t, 20, set.Child("c").Y)
+
+	// overlapping a changed child is still rejected
+	err := rekt.NewBatch(set).MoveRectangle("c", 140, 20).Apply()
+
+	var batchErr *rekt.BatchError[string]
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, []rekt.BatchFailure[string]{
+		{ID: "b", Err: rekt.ErrChildrenOverlap},
+		{ID: "c", Err: rekt.ErrChildrenOverlap},
+	}, batchErr.Failures)
+}
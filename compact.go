@@ -0,0 +1,206 @@
+package rekt
+
+import (
+	"sort"
+)
+
+// maxCompactPasses is the number of Left/Top passes CompactToOrigin will make before giving up on
+// the layout settling
+const maxCompactPasses = 100
+
+// SetMove describes a set that was moved by a compaction
+type SetMove[T comparable] struct {
+	Set *Set[T]
+	// From and To are the world space positions of the set before and after the move
+	From Point
+	To   Point
+}
+
+// Compact slides the sets toward the given edge closing up any gaps between them
+//
+// sets are processed starting with the one closest to the edge, each slides until its bounding
+// box touches a set that has already been processed or it reaches the edge of the bounding box
+// of all the sets
+//
+// only sets that share some of their span across the axis of the slide block each other, so the
+// relative order is kept between sets that are side by side (in the same row for Left/Right or
+// column for Top/Bottom) but a set can slide past one that is placed diagonally from it
+//
+// a set will not be moved if doing so would make its children overlap those of another set (this
+// can only happen when the bounding boxes of sets interleave)
+//
+// the sets that were moved are returned in the order they were processed
+func Compact[T comparable](sets []*Set[T], toward Edge) []SetMove[T] {
+	bounds := outerBounds(placedBounds(sets))
+
+	switch toward {
+	case Top:
+		return compact(sets, toward, bounds.Y)
+	case Right:
+		return compact(sets, toward, bounds.W)
+	case Bottom:
+		return compact(sets, toward, bounds.Z)
+	default:
+		return compact(sets, Left, bounds.X)
+	}
+}
+
+// CompactToOrigin slides the sets up and to the left toward the world space origin until none of
+// them can move any further
+// see Compact for the rules each slide follows
+//
+// each set that moved is reported once with its start and final positions, in the order they
+// first moved
+func CompactToOrigin[T comparable](sets []*Set[T], origin Point) []SetMove[T] {
+	var moves []SetMove[T]
+
+	for pass := 0; pass < maxCompactPasses; pass++ {
+		passMoves := append(compact(sets, Left, origin.X), compact(sets, Top, origin.Y)...)
+		if len(passMoves) == 0 {
+			break
+		}
+
+		moves = mergeMoves(moves, passMoves)
+	}
+
+	return moves
+}
+
+// Compact slides the sets in the layout toward the given edge, see Compact for more details
+func (layout *Layout[T]) Compact(toward Edge) []SetMove[T] {
+	return Compact(layout.sets, toward)
+}
+
+// CompactToOrigin slides the sets in the layout toward the origin, see CompactToOrigin for more
+// details
+func (layout *Layout[T]) CompactToOrigin(origin Point) []SetMove[T] {
+	return CompactToOrigin(layout.sets, origin)
+}
+
+// compact slides the sets toward the edge, stopping at limit
+// limit is the world space coordinate on the axis of the edge that no set may pass
+func compact[T comparable](sets []*Set[T], toward Edge, limit int) []SetMove[T] {
+	var (
+		moves   []SetMove[T]
+		order   []*Set[T]
+		placed  []Rectangle[T]
+		forward = toward == Right || toward == Bottom
+	)
+
+	for _, set := range sets {
+		if _, ok := set.WorldBounds(); ok {
+			order = append(order, set)
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		a, _ := order[i].WorldBounds()
+		b, _ := order[j].WorldBounds()
+
+		if forward {
+			return leadingEdge(a, toward) > leadingEdge(b, toward)
+		}
+
+		return leadingEdge(a, toward) < leadingEdge(b, toward)
+	})
+
+	for _, set := range order {
+		bounds, _ := set.WorldBounds()
+		lead := leadingEdge(bounds, toward)
+		stop := limit
+
+		for _, other := range placed {
+			if !sharesSpan(bounds, other, toward) {
+				continue
+			}
+
+			trailing := leadingEdge(other, toward.Opposite())
+			if forward && trailing >= lead {
+				stop = min(stop, trailing)
+			} else if !forward && trailing <= lead {
+				stop = max(stop, trailing)
+			}
+		}
+
+		// sets already past the limit are left where they are rather than being pulled back
+		if (forward && stop < lead) || (!forward && stop > lead) {
+			stop = lead
+		}
+
+		if stop != lead && !slideSet(sets, set, toward, stop-lead) {
+			stop = lead
+		}
+
+		if stop != lead {
+			moves = append(moves, SetMove[T]{
+				Set:  set,
+				From: slideOffset(NewPoint(set.X, set.Y), toward, lead-stop),
+				To:   NewPoint(set.X, set.Y),
+			})
+		}
+
+		bounds, _ = set.WorldBounds()
+		placed = append(placed, bounds)
+	}
+
+	return moves
+}
+
+// slideSet moves the set by distance along the axis of the edge
+// if the move would make the set overlap the children of any other set it is undone and false
+// is returned
+func slideSet[T comparable](sets []*Set[T], set *Set[T], toward Edge, distance int) bool {
+	from := NewPoint(set.X, set.Y)
+	to := slideOffset(from, toward, distance)
+	set.Move(to.X, to.Y)
+
+	for _, other := range sets {
+		if other != set && setsOverlap(set, other) {
+			set.Move(from.X, from.Y)
+			return false
+		}
+	}
+
+	return true
+}
+
+// slideOffset moves the point by distance along the axis of the edge
+func slideOffset(point Point, toward Edge, distance int) Point {
+	if toward == Left || toward == Right {
+		return point.Offset(distance, 0)
+	}
+
+	return point.Offset(0, distance)
+}
+
+// leadingEdge returns the coordinate of the given edge of the rectangle
+func leadingEdge[T any](rect Rectangle[T], edge Edge) int {
+	switch edge {
+	case Top:
+		return rect.Y
+	case Right:
+		return rect.W
+	case Bottom:
+		return rect.Z
+	default:
+		return rect.X
+	}
+}
+
+// mergeMoves adds the moves to the existing list, sets that have already moved keep their
+// original From position and have their To updated
+func mergeMoves[T comparable](moves, next []SetMove[T]) []SetMove[T] {
+outer:
+	for _, move := range next {
+		for i := range moves {
+			if moves[i].Set == move.Set {
+				moves[i].To = move.To
+				continue outer
+			}
+		}
+
+		moves = append(moves, move)
+	}
+
+	return moves
+}
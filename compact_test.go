@@ -0,0 +1,146 @@
+package rekt_test
+
+import (
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+// gappedSets builds a row of three sets with gaps between them and a fourth set below the first
+// which has a gap above it
+func gappedSets() []*rekt.Set[string] {
+	return []*rekt.Set[string]{
+		layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+		layoutSet("b", 150, 20, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+		layoutSet("c", 400, 0, rekt.NewRectangle("c-1", 0, 0, 100, 50)),
+		layoutSet("d", 0, 200, rekt.NewRectangle("d-1", 0, 0, 100, 100)),
+	}
+}
+
+func setPositions(sets []*rekt.Set[string]) []rekt.Point {
+	var points []rekt.Point
+	for _, set := range sets {
+		points = append(points, rekt.NewPoint(set.X, set.Y))
+	}
+
+	return points
+}
+
+var compactTests = []struct {
+	name     string
+	toward   rekt.Edge
+	expected []rekt.Point
+	moved    []string
+}{
+	{
+		"left", rekt.Left,
+		[]rekt.Point{{0, 0}, {100, 20}, {200, 0}, {0, 200}},
+		[]string{"b", "c"},
+	},
+	{
+		"right", rekt.Right,
+		[]rekt.Point{{200, 0}, {300, 20}, {400, 0}, {400, 200}},
+		// c has the furthest right edge so is processed first and doesn't move, b then stops
+		// against c leaving a to stop against b
+		[]string{"b", "a", "d"},
+	},
+	{
+		"top", rekt.Top,
+		[]rekt.Point{{0, 0}, {150, 0}, {400, 0}, {0, 100}},
+		[]string{"b", "d"},
+	},
+	{
+		"bottom", rekt.Bottom,
+		[]rekt.Point{{0, 100}, {150, 200}, {400, 250}, {0, 200}},
+		[]string{"b", "a", "c"},
+	},
+}
+
+func TestCompact(t *testing.T) {
+	for _, testCase := range compactTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			sets := gappedSets()
+			moves := rekt.Compact(sets, testCase.toward)
+
+			require.Equal(t, testCase.expected, setPositions(sets))
+
+			var moved []string
+			for _, move := range moves {
+				moved = append(moved, move.Set.ID)
+				require.Equal(t, rekt.NewPoint(move.Set.X, move.Set.Y), move.To)
+				require.NotEqual(t, move.From, move.To)
+			}
+			require.Equal(t, testCase.moved, moved)
+
+			_, err := rekt.NewLayout(sets...)
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestCompactMoveFrom(t *testing.T) {
+	sets := gappedSets()
+	moves := rekt.Compact(sets, rekt.Left)
+
+	require.Equal(t, rekt.SetMove[string]{Set: sets[1], From: rekt.NewPoint(150, 20), To: rekt.NewPoint(100, 20)}, moves[0])
+	require.Equal(t, rekt.SetMove[string]{Set: sets[2], From: rekt.NewPoint(400, 0), To: rekt.NewPoint(200, 0)}, moves[1])
+	require.Nil(t, rekt.Compact(sets, rekt.Left))
+}
+
+func TestCompactInterleaved(t *testing.T) {
+	// the bounding boxes of these sets interleave so the hook can't slide left without its
+	// children overlapping the l shape
+	lShape := layoutSet("l-shape", 0, 0,
+		rekt.NewRectangle("l-shape-1", 0, 0, 10, 100),
+		rekt.NewRectangle("l-shape-2", 10, 90, 100, 100),
+	)
+	hook := layoutSet("hook", 20, 0,
+		rekt.NewRectangle("hook-1", 0, 0, 80, 80),
+		rekt.NewRectangle("hook-2", 80, 0, 100, 200),
+	)
+
+	require.Nil(t, rekt.Compact([]*rekt.Set[string]{lShape, hook}, rekt.Left))
+	require.Equal(t, 20, hook.X)
+}
+
+func TestCompactToOrigin(t *testing.T) {
+	sets := []*rekt.Set[string]{
+		layoutSet("a", 50, 50, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+		layoutSet("b", 300, 300, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+		layoutSet("past", -500, 0, rekt.NewRectangle("past-1", 0, 0, 100, 100)),
+	}
+
+	layout, err := rekt.NewLayout(sets...)
+	require.Nil(t, err)
+
+	moves := layout.CompactToOrigin(rekt.NewPoint(0, 0))
+
+	require.Equal(t, []rekt.Point{{0, 0}, {0, 100}, {-500, 0}}, setPositions(sets))
+	require.Equal(t, []rekt.SetMove[string]{
+		{Set: sets[0], From: rekt.NewPoint(50, 50), To: rekt.NewPoint(0, 0)},
+		{Set: sets[1], From: rekt.NewPoint(300, 300), To: rekt.NewPoint(0, 100)},
+	}, moves)
+}
+
+func TestCompactToOriginDiagonal(t *testing.T) {
+	// b starts below and to the right of a without sharing a row or column with it
+	sets := []*rekt.Set[string]{
+		layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+		layoutSet("b", 100, 200, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+	}
+
+	// nothing blocks b on the way left so it ends up directly below a rather than beside it
+	rekt.CompactToOrigin(sets, rekt.NewPoint(0, 0))
+	require.Equal(t, []rekt.Point{{0, 0}, {0, 100}}, setPositions(sets))
+}
+
+func TestLayoutCompact(t *testing.T) {
+	layout, _ := rekt.NewLayout(gappedSets()...)
+
+	require.Len(t, layout.Compact(rekt.Left), 2)
+	require.Error(t, layout.ValidateConnectivity())
+
+	require.Len(t, layout.Compact(rekt.Top), 2)
+	require.Nil(t, layout.ValidateConnectivity())
+}
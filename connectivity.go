@@ -0,0 +1,81 @@
+package rekt
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrDisconnected = errors.New("not all rectangles can be reached from each other")
+)
+
+// ConnectivityError describes the groups of rectangles that cannot reach each other
+// it wraps ErrDisconnected so can be checked with errors.Is
+type ConnectivityError[T any] struct {
+	// Components holds each group of rectangles that can reach each other
+	// they are ordered by size with the largest group first
+	Components [][]Rectangle[T]
+	// Unreachable holds every rectangle that cannot be reached from the largest group
+	Unreachable []Rectangle[T]
+}
+
+// Error implements error
+func (err *ConnectivityError[T]) Error() string {
+	ids := make([]T, 0, len(err.Unreachable))
+	for _, rect := range err.Unreachable {
+		ids = append(ids, rect.ID)
+	}
+
+	return fmt.Sprintf("%s: %d component(s), unreachable %v", ErrDisconnected, len(err.Components), ids)
+}
+
+// Unwrap allows errors.Is to match against ErrDisconnected
+func (err *ConnectivityError[T]) Unwrap() error {
+	return ErrDisconnected
+}
+
+var _ error = (*ConnectivityError[int])(nil)
+
+// ValidateConnectivity checks that every child of the given sets (including the children of any
+// nested sets) can be reached from every other child by crossing shared edges
+//
+// two children are considered connected if they sit either side of an edge and share a section
+// of it with a non zero length, children that only meet at a corner are not connected
+//
+// if any children cannot be reached a *ConnectivityError will be returned
+func ValidateConnectivity[T comparable](sets ...*Set[T]) error {
+	return ValidateConnectivityWithin(0, sets...)
+}
+
+// ValidateConnectivityWithin works the same as ValidateConnectivity but treats children with
+// edges up to tolerance units apart as connected, see NewWorldGraphWithin
+func ValidateConnectivityWithin[T comparable](tolerance int, sets ...*Set[T]) error {
+	graph := NewWorldGraphWithin(tolerance, sets...)
+
+	components := graph.Components()
+	if len(components) < 2 {
+		return nil
+	}
+
+	err := &ConnectivityError[T]{}
+	for i, component := range components {
+		rects := make([]Rectangle[T], 0, len(component))
+		for _, node := range component {
+			rects = append(rects, graph.Nodes[node].Rectangle)
+		}
+
+		err.Components = append(err.Components, rects)
+		if i > 0 {
+			err.Unreachable = append(err.Unreachable, rects...)
+		}
+	}
+
+	return err
+}
+
+// ValidateConnectivity checks that every child in the layout can be reached from every other
+// using the tolerance of the layout (see SetTolerance)
+// see ValidateConnectivity for more details
+func (layout *Layout[T]) ValidateConnectivity() error {
+	return ValidateConnectivityWithin(layout.tolerance, layout.sets...)
+}
@@ -0,0 +1,119 @@
+package rekt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+func rectIDs(rects []rekt.Rectangle[string]) []string {
+	var ids []string
+	for _, rect := range rects {
+		ids = append(ids, rect.ID)
+	}
+
+	return ids
+}
+
+var validateConnectivityTests = []struct {
+	name        string
+	sets        []*rekt.Set[string]
+	components  [][]string
+	unreachable []string
+}{
+	{"no sets", nil, nil, nil},
+	{
+		"single child",
+		[]*rekt.Set[string]{layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100))},
+		nil,
+		nil,
+	},
+	{
+		"connected within set",
+		[]*rekt.Set[string]{layoutSet("a", 0, 0,
+			rekt.NewRectangle("a-1", 0, 0, 100, 100),
+			rekt.NewRectangle("a-2", 100, 50, 200, 150),
+		)},
+		nil,
+		nil,
+	},
+	{
+		"connected across sets",
+		[]*rekt.Set[string]{
+			layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+			layoutSet("b", 0, 100, rekt.NewRectangle("b-1", 50, 0, 150, 100)),
+		},
+		nil,
+		nil,
+	},
+	{
+		"corners do not connect",
+		[]*rekt.Set[string]{
+			layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+			layoutSet("b", 100, 100, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+		},
+		[][]string{{"a-1"}, {"b-1"}},
+		[]string{"b-1"},
+	},
+	{
+		"gap between sets",
+		[]*rekt.Set[string]{
+			layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+			layoutSet("b", 101, 0, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+		},
+		[][]string{{"a-1"}, {"b-1"}},
+		[]string{"b-1"},
+	},
+	{
+		"largest component first",
+		[]*rekt.Set[string]{
+			layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+			layoutSet("b", 500, 0,
+				rekt.NewRectangle("b-1", 0, 0, 100, 100),
+				rekt.NewRectangle("b-2", 100, 0, 200, 100),
+			),
+			layoutSet("c", 1000, 0, rekt.NewRectangle("c-1", 0, 0, 100, 100)),
+		},
+		[][]string{{"b-1", "b-2"}, {"a-1"}, {"c-1"}},
+		[]string{"a-1", "c-1"},
+	},
+}
+
+func TestValidateConnectivity(t *testing.T) {
+	for _, testCase := range validateConnectivityTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := rekt.ValidateConnectivity(testCase.sets...)
+			if testCase.components == nil {
+				require.Nil(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, rekt.ErrDisconnected)
+
+			var connectivityErr *rekt.ConnectivityError[string]
+			require.True(t, errors.As(err, &connectivityErr))
+
+			var components [][]string
+			for _, component := range connectivityErr.Components {
+				components = append(components, rectIDs(component))
+			}
+
+			require.Equal(t, testCase.components, components)
+			require.Equal(t, testCase.unreachable, rectIDs(connectivityErr.Unreachable))
+		})
+	}
+}
+
+func TestLayoutValidateConnectivity(t *testing.T) {
+	a := layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100))
+	b := layoutSet("b", 200, 0, rekt.NewRectangle("b-1", 0, 0, 100, 100))
+	layout, _ := rekt.NewLayout(a, b)
+
+	require.ErrorIs(t, layout.ValidateConnectivity(), rekt.ErrDisconnected)
+	require.Contains(t, layout.ValidateConnectivity().Error(), "b-1")
+
+	require.Nil(t, layout.MoveSet(b, 100, 0))
+	require.Nil(t, layout.ValidateConnectivity())
+}
@@ -0,0 +1,314 @@
+package rekt
+
+import (
+	"math"
+)
+
+// Mapping defines how the position of the cursor along an edge is translated onto the
+// Rectangle on the other side of it
+type Mapping uint8
+
+const (
+	// MapAbsolute keeps the cursor at the same world space position along the edge
+	MapAbsolute Mapping = iota
+	// MapProportional keeps the cursor at the same relative position along the edge
+	// e.g. leaving half way down the right edge of one display will enter half way down the
+	// left edge of the next regardless of the heights of the two displays
+	MapProportional
+	// MapPhysical keeps the cursor at the same physical distance along the edge from the start of
+	// the section shared by both displays, this stops the cursor jumping when moving between
+	// displays of different pixel densities
+	// if the physical size of either display is not known it falls back to MapAbsolute
+	MapPhysical
+)
+
+// Crossing describes where the cursor lands after leaving a Rectangle across one of its edges
+type Crossing[T comparable] struct {
+	// Set is the Set that owns the destination Rectangle, this will be a nested set if the
+	// Rectangle belongs to one
+	Set *Set[T]
+	// Child is the destination Rectangle, its coordinates are relative to world space
+	Child Rectangle[T]
+	// Edge is the edge of the destination Rectangle that the cursor entered through
+	Edge Edge
+	// Point is the position of the cursor after the crossing, relative to world space
+	Point Point
+	// Warped is set when there was no neighbour directly on the other side of the edge and the
+	// destination was picked by a WarpPolicy
+	Warped bool
+}
+
+// ResolveCrossing works out where the cursor ends up after moving by dx,dy from the world space
+// point in the given sets, the content of any nested sets is included
+//
+// nil will be returned if:
+// - the starting point is not on any child of the sets
+// - the movement does not take the cursor out of the Rectangle it started on
+// - there is no Rectangle on the other side of the edge at the point the cursor left
+func ResolveCrossing[T comparable](sets []*Set[T], from Point, dx, dy int, mapping Mapping) *Crossing[T] {
+	return ResolveCrossingWith(sets, from, dx, dy, mapping, WarpBlock)
+}
+
+// ResolveCrossingWith works the same as ResolveCrossing but consults the WarpPolicy when there is
+// no Rectangle on the other side of the edge at the point the cursor left
+// nil will be returned if the policy blocks the cursor
+func ResolveCrossingWith[T comparable](
+	sets []*Set[T],
+	from Point,
+	dx, dy int,
+	mapping Mapping,
+	policy WarpPolicy,
+) *Crossing[T] {
+	return ResolveCrossingWithin(sets, from, dx, dy, mapping, policy, 0)
+}
+
+// ResolveCrossingWithin works the same as ResolveCrossingWith but treats a Rectangle up to
+// tolerance units beyond the edge as being on the other side of it, the cursor jumps the gap
+// without it counting towards the distance travelled into the destination
+//
+// warp candidates up to tolerance units away are passed to the policy with a Gap of 0 so they are
+// treated as touching the edge
+func ResolveCrossingWithin[T comparable](
+	sets []*Set[T],
+	from Point,
+	dx, dy int,
+	mapping Mapping,
+	policy WarpPolicy,
+	tolerance int,
+) *Crossing[T] {
+	_, source := offsetChildAt(sets, from)
+	if source == nil {
+		return nil
+	}
+
+	to := from.Offset(dx, dy)
+	if source.Contains(to) {
+		return nil
+	}
+
+	exit, at, overshoot := exitEdge(*source, from, to)
+
+	for _, set := range sets {
+		for _, owned := range set.contentTouchingWithin(*source, tolerance) {
+			coords, _ := source.neighbourCoordinatesWithin(owned.rect, exit, tolerance)
+			if coords == nil || !onEdgeSegment(*coords, exit, at) {
+				continue
+			}
+
+			return &Crossing[T]{
+				Set:   owned.set,
+				Child: owned.rect,
+				Edge:  exit.Opposite(),
+				Point: entryPoint(*source, owned.rect, *coords, exit, at, overshoot, mapping),
+			}
+		}
+	}
+
+	return warpCrossing(sets, *source, exit, at, overshoot, mapping, policy, tolerance)
+}
+
+// warpCrossing asks the policy where the cursor should go after leaving source at a point with
+// no neighbour directly on the other side of the edge
+// the position picked by the policy is then treated as the point the cursor left at and goes
+// through the mapping in the same way as a direct crossing
+// nil will be returned if the policy blocks the cursor
+func warpCrossing[T comparable](
+	sets []*Set[T],
+	source Rectangle[T],
+	exit Edge,
+	at, overshoot int,
+	mapping Mapping,
+	policy WarpPolicy,
+	tolerance int,
+) *Crossing[T] {
+	var (
+		candidates []WarpCandidate
+		owned      []ownedRectangle[T]
+	)
+
+	for _, set := range sets {
+		strip, ok := warpStrip(source, set.Rectangle, exit)
+		if !ok {
+			continue
+		}
+
+		for _, content := range set.contentOverlapping(strip) {
+			if candidate, ok := warpCandidate(source, content.rect, exit); ok {
+				if candidate.Gap <= tolerance {
+					candidate.Gap = 0
+				}
+
+				candidates = append(candidates, candidate)
+				owned = append(owned, content)
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	start, end := source.X, source.W
+	if exit == Left || exit == Right {
+		start, end = source.Y, source.Z
+	}
+
+	i, position, ok := policy.Warp(start, end, at, candidates)
+	if !ok || i < 0 || i >= len(candidates) {
+		return nil
+	}
+
+	dest := owned[i].rect
+	// candidates always share some of the span of the edge, see warpCandidate
+	coords := touchLine(source, dest, exit)
+
+	return &Crossing[T]{
+		Set:    owned[i].set,
+		Child:  dest,
+		Edge:   exit.Opposite(),
+		Point:  entryPoint(source, dest, *coords, exit, position, overshoot, mapping),
+		Warped: true,
+	}
+}
+
+// warpStrip returns the area beyond the exit edge of source, within the span of the edge, that
+// is covered by bounds
+// only content within the strip can be a warp candidate, see warpCandidate
+// false will be returned if bounds does not cover any of the area beyond the edge
+func warpStrip[T any](source, bounds Rectangle[T], exit Edge) (Rectangle[T], bool) {
+	strip := source
+
+	switch exit {
+	case Top:
+		strip.Y, strip.Z = bounds.Y, source.Y
+	case Right:
+		strip.X, strip.W = source.W, bounds.W
+	case Bottom:
+		strip.Y, strip.Z = source.Z, bounds.Z
+	case Left:
+		strip.X, strip.W = bounds.X, source.X
+	}
+
+	return strip, strip.W > strip.X && strip.Z > strip.Y
+}
+
+// offsetChildAt finds the child that contains the world space point from any of the given sets
+// or the sets nested within them, along with the set that owns it
+// the returned Rectangle will have its coordinates offset into world space
+func offsetChildAt[T comparable](sets []*Set[T], point Point) (*Set[T], *Rectangle[T]) {
+	for _, set := range sets {
+		if owned, ok := set.contentAt(point); ok {
+			return owned.set, &owned.rect
+		}
+	}
+
+	return nil, nil
+}
+
+// exitEdge works out which edge of rect the cursor passes through when travelling from -> to
+//
+// along with the edge it returns:
+// - at: the position along the edge (Y for Left/Right, X for Top/Bottom) that the cursor crossed
+// - overshoot: how far past the edge the cursor travelled
+//
+// if the movement leaves via a corner the edge crossed first wins, in the case of an exact
+// corner hit the horizontal edges (Left/Right) are preferred
+func exitEdge[T any](rect Rectangle[T], from, to Point) (edge Edge, at int, overshoot int) {
+	dx, dy := to.X-from.X, to.Y-from.Y
+
+	// steps taken along each axis before the cursor is outside of the rectangle
+	// 0 means the cursor does not leave on that axis
+	var xSteps, ySteps int
+	var xEdge, yEdge Edge
+
+	if to.X >= rect.W {
+		xEdge, xSteps = Right, rect.W-from.X
+	} else if to.X < rect.X {
+		xEdge, xSteps = Left, from.X-rect.X+1
+	}
+
+	if to.Y >= rect.Z {
+		yEdge, ySteps = Bottom, rect.Z-from.Y
+	} else if to.Y < rect.Y {
+		yEdge, ySteps = Top, from.Y-rect.Y+1
+	}
+
+	// comparing xSteps/|dx| against ySteps/|dy| without the division
+	if xSteps != 0 && (ySteps == 0 || xSteps*abs(dy) <= ySteps*abs(dx)) {
+		at = clamp(from.Y+dy*xSteps/abs(dx), rect.Y, rect.Z-1)
+		overshoot = abs(dx) - xSteps
+
+		return xEdge, at, overshoot
+	}
+
+	at = clamp(from.X+dx*ySteps/abs(dy), rect.X, rect.W-1)
+	overshoot = abs(dy) - ySteps
+
+	return yEdge, at, overshoot
+}
+
+// onEdgeSegment checks if the position along the edge falls within the given edge coordinates
+func onEdgeSegment[T any](coords EdgeCoordinates[T], edge Edge, at int) bool {
+	if edge == Left || edge == Right {
+		return at >= coords.Y && at < coords.Z
+	}
+
+	return at >= coords.X && at < coords.W
+}
+
+// entryPoint calculates the world space position of the cursor within the destination Rectangle
+// after leaving source via the exit edge
+func entryPoint[T any](
+	source, dest Rectangle[T],
+	coords EdgeCoordinates[T],
+	exit Edge,
+	at, overshoot int,
+	mapping Mapping,
+) Point {
+	var srcStart, srcLength, destStart, destLength int
+
+	if exit == Left || exit == Right {
+		srcStart, srcLength = source.Y, source.Height()
+		destStart, destLength = dest.Y, dest.Height()
+	} else {
+		srcStart, srcLength = source.X, source.Width()
+		destStart, destLength = dest.X, dest.Width()
+	}
+
+	switch mapping {
+	case MapProportional:
+		at = destStart + (at-srcStart)*destLength/srcLength
+	case MapPhysical:
+		at = physicalEdgePosition(source, dest, coords, exit, at)
+	}
+
+	at = clamp(at, destStart, destStart+destLength-1)
+
+	switch exit {
+	case Right:
+		return NewPoint(dest.X+clamp(overshoot, 0, dest.Width()-1), at)
+	case Left:
+		return NewPoint(dest.W-1-clamp(overshoot, 0, dest.Width()-1), at)
+	case Bottom:
+		return NewPoint(at, dest.Y+clamp(overshoot, 0, dest.Height()-1))
+	default:
+		return NewPoint(at, dest.Z-1-clamp(overshoot, 0, dest.Height()-1))
+	}
+}
+
+// physicalEdgePosition maps the position along the exit edge of source onto dest so that it is
+// the same physical distance from the start of the shared section of the edge
+// if the physical size of either rectangle is not known the position is returned unchanged
+func physicalEdgePosition[T any](source, dest Rectangle[T], coords EdgeCoordinates[T], exit Edge, at int) int {
+	if !source.HasPhysicalSize() || !dest.HasPhysicalSize() {
+		return at
+	}
+
+	if exit == Left || exit == Right {
+		mm := float64(at-coords.Y) * source.mmPerUnitY()
+		return coords.Y + int(math.Round(mm/dest.mmPerUnitY()))
+	}
+
+	mm := float64(at-coords.X) * source.mmPerUnitX()
+	return coords.X + int(math.Round(mm/dest.mmPerUnitX()))
+}
@@ -0,0 +1,137 @@
+package rekt_test
+
+import (
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+func crossingSets() []*rekt.Set[string] {
+	var setA, _ = rekt.NewSet("set-a", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("a-1", 0, 0, 100, 100),
+		rekt.NewRectangle("a-2", 100, 0, 200, 50),
+	})
+	var setB, _ = rekt.NewSet("set-b", 200, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("b-1", 0, 0, 100, 200),
+	})
+	var setC, _ = rekt.NewSet("set-c", 0, 100, []rekt.Rectangle[string]{
+		rekt.NewRectangle("c-1", 0, 0, 100, 50),
+	})
+
+	return []*rekt.Set[string]{setA, setB, setC}
+}
+
+type expectedCrossing struct {
+	set   string
+	child string
+	edge  rekt.Edge
+	point rekt.Point
+}
+
+var resolveCrossingTests = []struct {
+	name     string
+	from     rekt.Point
+	dx       int
+	dy       int
+	mapping  rekt.Mapping
+	expected *expectedCrossing
+}{
+	{"off all sets", rekt.NewPoint(500, 500), 10, 0, rekt.MapAbsolute, nil},
+	{"no crossing", rekt.NewPoint(50, 50), 10, 0, rekt.MapAbsolute, nil},
+	{"no neighbour at point", rekt.NewPoint(50, 50), 60, 0, rekt.MapAbsolute, nil},
+	{"off outer edge", rekt.NewPoint(5, 5), -10, 0, rekt.MapAbsolute, nil},
+	{
+		"right within set", rekt.NewPoint(50, 10), 60, 0, rekt.MapAbsolute,
+		&expectedCrossing{"set-a", "a-2", rekt.Left, rekt.NewPoint(110, 10)},
+	},
+	{
+		"right between sets absolute", rekt.NewPoint(190, 10), 20, 0, rekt.MapAbsolute,
+		&expectedCrossing{"set-b", "b-1", rekt.Left, rekt.NewPoint(210, 10)},
+	},
+	{
+		"right between sets proportional", rekt.NewPoint(190, 10), 20, 0, rekt.MapProportional,
+		&expectedCrossing{"set-b", "b-1", rekt.Left, rekt.NewPoint(210, 40)},
+	},
+	{
+		"left between sets", rekt.NewPoint(205, 20), -10, 0, rekt.MapAbsolute,
+		&expectedCrossing{"set-a", "a-2", rekt.Right, rekt.NewPoint(195, 20)},
+	},
+	{
+		"left below neighbour", rekt.NewPoint(205, 100), -10, 0, rekt.MapProportional,
+		nil,
+	},
+	{
+		"down between sets", rekt.NewPoint(50, 90), 0, 20, rekt.MapAbsolute,
+		&expectedCrossing{"set-c", "c-1", rekt.Top, rekt.NewPoint(50, 110)},
+	},
+	{
+		"up between sets", rekt.NewPoint(50, 105), 0, -10, rekt.MapAbsolute,
+		&expectedCrossing{"set-a", "a-1", rekt.Bottom, rekt.NewPoint(50, 95)},
+	},
+	{
+		"diagonal leaves bottom first", rekt.NewPoint(95, 95), 10, 20, rekt.MapAbsolute,
+		&expectedCrossing{"set-c", "c-1", rekt.Top, rekt.NewPoint(97, 115)},
+	},
+	{
+		"overshoot is clamped to destination", rekt.NewPoint(50, 99), 0, 500, rekt.MapAbsolute,
+		&expectedCrossing{"set-c", "c-1", rekt.Top, rekt.NewPoint(50, 149)},
+	},
+}
+
+func TestResolveCrossing(t *testing.T) {
+	sets := crossingSets()
+
+	for _, testCase := range resolveCrossingTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			crossing := rekt.ResolveCrossing(sets, testCase.from, testCase.dx, testCase.dy, testCase.mapping)
+			if testCase.expected == nil {
+				require.Nil(t, crossing)
+				return
+			}
+
+			require.NotNil(t, crossing)
+			require.Equal(t, testCase.expected.set, crossing.Set.ID)
+			require.Equal(t, testCase.expected.child, crossing.Child.ID)
+			require.Equal(t, testCase.expected.edge, crossing.Edge)
+			require.Equal(t, testCase.expected.point, crossing.Point)
+			require.True(t, crossing.Child.Contains(crossing.Point))
+		})
+	}
+}
+
+var resolveCrossingPhysicalTests = []struct {
+	name     string
+	mapping  rekt.Mapping
+	expected rekt.Point
+}{
+	{"absolute", rekt.MapAbsolute, rekt.NewPoint(105, 40)},
+	{"proportional", rekt.MapProportional, rekt.NewPoint(105, 80)},
+	{"physical", rekt.MapPhysical, rekt.NewPoint(105, 20)},
+}
+
+func TestResolveCrossingPhysical(t *testing.T) {
+	// 1 unit is 1mm on the dense display and 2mm on the sparse one
+	dense := layoutSet("dense", 0, 0, rekt.NewRectangle("dense-1", 0, 0, 100, 100).WithPhysicalSize(100, 100))
+	sparse := layoutSet("sparse", 100, 0, rekt.NewRectangle("sparse-1", 0, 0, 200, 200).WithPhysicalSize(400, 400))
+	sets := []*rekt.Set[string]{dense, sparse}
+
+	for _, testCase := range resolveCrossingPhysicalTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			crossing := rekt.ResolveCrossing(sets, rekt.NewPoint(95, 40), 10, 0, testCase.mapping)
+
+			require.NotNil(t, crossing)
+			require.Equal(t, testCase.expected, crossing.Point)
+		})
+	}
+}
+
+func TestResolveCrossingPhysicalUnknownSize(t *testing.T) {
+	dense := layoutSet("dense", 0, 0, rekt.NewRectangle("dense-1", 0, 0, 100, 100).WithPhysicalSize(100, 100))
+	unknown := layoutSet("unknown", 100, 0, rekt.NewRectangle("unknown-1", 0, 0, 200, 200))
+
+	crossing := rekt.ResolveCrossing([]*rekt.Set[string]{dense, unknown}, rekt.NewPoint(95, 40), 10, 0, rekt.MapPhysical)
+
+	require.NotNil(t, crossing)
+	require.Equal(t, rekt.NewPoint(105, 40), crossing.Point)
+}
@@ -22,6 +22,24 @@ func (e Edge) String() string {
 	}
 }
 
+// Opposite returns the edge on the other side of a rectangle
+// e.g. the cursor leaving a rectangle via its Right edge will enter its neighbour via the Left
+func (e Edge) Opposite() Edge {
+	switch e {
+	case Top:
+		return Bottom
+	case Right:
+		return Left
+	case Bottom:
+		return Top
+	case Left:
+		return Right
+
+	default:
+		return e
+	}
+}
+
 var _ fmt.Stringer = (*Edge)(nil)
 
 const (
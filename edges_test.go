@@ -25,3 +25,22 @@ func TestEdgeString(t *testing.T) {
 		})
 	}
 }
+
+var edgesOppositeTests = []struct {
+	edge     rekt.Edge
+	expected rekt.Edge
+}{
+	{rekt.Top, rekt.Bottom},
+	{rekt.Right, rekt.Left},
+	{rekt.Bottom, rekt.Top},
+	{rekt.Left, rekt.Right},
+	{rekt.Edge(100), rekt.Edge(100)},
+}
+
+func TestEdgeOpposite(t *testing.T) {
+	for _, testCase := range edgesOppositeTests {
+		t.Run(testCase.edge.String(), func(t *testing.T) {
+			require.Equal(t, testCase.expected, testCase.edge.Opposite())
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package rekt
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// rectangleEncoding is the on disk format of a Rectangle
+// display information is optional and left out when not set
+type rectangleEncoding[T any] struct {
+	ID       T       `json:"id" yaml:"id"`
+	X        int     `json:"x" yaml:"x"`
+	Y        int     `json:"y" yaml:"y"`
+	W        int     `json:"w" yaml:"w"`
+	Z        int     `json:"z" yaml:"z"`
+	Scale    float64 `json:"scale,omitempty" yaml:"scale,omitempty"`
+	WidthMM  int     `json:"width_mm,omitempty" yaml:"width_mm,omitempty"`
+	HeightMM int     `json:"height_mm,omitempty" yaml:"height_mm,omitempty"`
+	Rotation int     `json:"rotation,omitempty" yaml:"rotation,omitempty"`
+	FlipX    bool    `json:"flip_x,omitempty" yaml:"flip_x,omitempty"`
+	FlipY    bool    `json:"flip_y,omitempty" yaml:"flip_y,omitempty"`
+}
+
+// setEncoding is the on disk format of a Set
+// the bottom right of the set is not stored as it is calculated from the children
+type setEncoding[T comparable] struct {
+	ID       T              `json:"id" yaml:"id"`
+	X        int            `json:"x" yaml:"x"`
+	Y        int            `json:"y" yaml:"y"`
+	Children []Rectangle[T] `json:"children" yaml:"children"`
+	Sets     []*Set[T]      `json:"sets,omitempty" yaml:"sets,omitempty"`
+}
+
+// leafEncoding is the on disk format of a Leaf
+type leafEncoding[T any] struct {
+	rectangleEncoding[T] `yaml:",inline"`
+	Path                 []T `json:"path" yaml:"path"`
+}
+
+// layoutEncoding is the on disk format of a Layout
+type layoutEncoding[T comparable] struct {
+	Sets      []*Set[T] `json:"sets" yaml:"sets"`
+	Tolerance int       `json:"tolerance,omitempty" yaml:"tolerance,omitempty"`
+}
+
+var (
+	_ json.Marshaler   = Rectangle[int]{}
+	_ json.Unmarshaler = (*Rectangle[int])(nil)
+	_ yaml.Marshaler   = Rectangle[int]{}
+	_ yaml.Unmarshaler = (*Rectangle[int])(nil)
+
+	_ json.Marshaler   = Leaf[int]{}
+	_ json.Unmarshaler = (*Leaf[int])(nil)
+	_ yaml.Marshaler   = Leaf[int]{}
+	_ yaml.Unmarshaler = (*Leaf[int])(nil)
+
+	_ json.Marshaler   = LocalRectangle[int]{}
+	_ json.Unmarshaler = (*LocalRectangle[int])(nil)
+	_ yaml.Marshaler   = LocalRectangle[int]{}
+	_ yaml.Unmarshaler = (*LocalRectangle[int])(nil)
+
+	_ json.Marshaler   = WorldRectangle[int]{}
+	_ json.Unmarshaler = (*WorldRectangle[int])(nil)
+	_ yaml.Marshaler   = WorldRectangle[int]{}
+	_ yaml.Unmarshaler = (*WorldRectangle[int])(nil)
+
+	_ json.Marshaler   = Set[int]{}
+	_ json.Unmarshaler = (*Set[int])(nil)
+	_ yaml.Marshaler   = Set[int]{}
+	_ yaml.Unmarshaler = (*Set[int])(nil)
+
+	_ json.Marshaler   = (*Layout[int])(nil)
+	_ json.Unmarshaler = (*Layout[int])(nil)
+	_ yaml.Marshaler   = (*Layout[int])(nil)
+	_ yaml.Unmarshaler = (*Layout[int])(nil)
+)
+
+// MarshalJSON implements json.Marshaler
+func (rect Rectangle[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rect.encode())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+// the decoded rectangle is not validated so that any rectangle (including the zero value) can be
+// round tripped, rectangles are validated when they are used to build a Set
+func (rect *Rectangle[T]) UnmarshalJSON(data []byte) error {
+	var encoded rectangleEncoding[T]
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	return rect.decode(encoded)
+}
+
+// MarshalYAML implements yaml.Marshaler
+func (rect Rectangle[T]) MarshalYAML() (interface{}, error) {
+	return rect.encode(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+// see UnmarshalJSON for how the decoded rectangle is validated
+func (rect *Rectangle[T]) UnmarshalYAML(value *yaml.Node) error {
+	var encoded rectangleEncoding[T]
+	if err := value.Decode(&encoded); err != nil {
+		return err
+	}
+
+	return rect.decode(encoded)
+}
+
+// encode converts the rectangle into its on disk format
+func (rect Rectangle[T]) encode() rectangleEncoding[T] {
+	return rectangleEncoding[T]{
+		ID:       rect.ID,
+		X:        rect.X,
+		Y:        rect.Y,
+		W:        rect.W,
+		Z:        rect.Z,
+		Scale:    rect.Scale,
+		WidthMM:  rect.WidthMM,
+		HeightMM: rect.HeightMM,
+		Rotation: rect.Orientation.Rotation.Degrees(),
+		FlipX:    rect.Orientation.FlipX,
+		FlipY:    rect.Orientation.FlipY,
+	}
+}
+
+// decode populates the rectangle from its on disk format
+func (rect *Rectangle[T]) decode(encoded rectangleEncoding[T]) error {
+	rotation, ok := RotationFromDegrees(encoded.Rotation)
+	if !ok {
+		return ErrBadRotation
+	}
+
+	decoded := NewRectangle(encoded.ID, encoded.X, encoded.Y, encoded.W, encoded.Z).
+		WithScale(encoded.Scale).
+		WithPhysicalSize(encoded.WidthMM, encoded.HeightMM)
+
+	// the stored coords are already in their rotated form so the orientation is set directly
+	// rather than via WithOrientation
+	decoded.Orientation = Orientation{
+		Rotation: rotation,
+		FlipX:    encoded.FlipX,
+		FlipY:    encoded.FlipY,
+	}
+
+	*rect = decoded
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+//
+// Leaf has its own marshallers as the ones promoted from the embedded Rectangle would leave out
+// the Path, like Set they have value receivers so they are not hidden when marshalled by value
+func (leaf Leaf[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(leaf.encode())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (leaf *Leaf[T]) UnmarshalJSON(data []byte) error {
+	var encoded leafEncoding[T]
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	return leaf.decode(encoded)
+}
+
+// MarshalYAML implements yaml.Marshaler
+func (leaf Leaf[T]) MarshalYAML() (interface{}, error) {
+	return leaf.encode(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (leaf *Leaf[T]) UnmarshalYAML(value *yaml.Node) error {
+	var encoded leafEncoding[T]
+	if err := value.Decode(&encoded); err != nil {
+		return err
+	}
+
+	return leaf.decode(encoded)
+}
+
+// encode converts the leaf into its on disk format
+func (leaf Leaf[T]) encode() leafEncoding[T] {
+	return leafEncoding[T]{
+		rectangleEncoding: leaf.Rectangle.encode(),
+		Path:              leaf.Path,
+	}
+}
+
+// decode populates the leaf from its on disk format
+func (leaf *Leaf[T]) decode(encoded leafEncoding[T]) error {
+	var rect Rectangle[T]
+	if err := rect.decode(encoded.rectangleEncoding); err != nil {
+		return err
+	}
+
+	leaf.Rectangle = rect
+	leaf.Path = encoded.Path
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+// the rectangle is encoded in the same way as a plain Rectangle
+func (rect LocalRectangle[T]) MarshalJSON() ([]byte, error) {
+	return rect.Rectangle.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (rect *LocalRectangle[T]) UnmarshalJSON(data []byte) error {
+	return rect.Rectangle.UnmarshalJSON(data)
+}
+
+// MarshalYAML implements yaml.Marshaler
+func (rect LocalRectangle[T]) MarshalYAML() (interface{}, error) {
+	return rect.Rectangle.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (rect *LocalRectangle[T]) UnmarshalYAML(value *yaml.Node) error {
+	return rect.Rectangle.UnmarshalYAML(value)
+}
+
+// MarshalJSON implements json.Marshaler
+// the rectangle is encoded in the same way as a plain Rectangle
+func (rect WorldRectangle[T]) MarshalJSON() ([]byte, error) {
+	return rect.Rectangle.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (rect *WorldRectangle[T]) UnmarshalJSON(data []byte) error {
+	return rect.Rectangle.UnmarshalJSON(data)
+}
+
+// MarshalYAML implements yaml.Marshaler
+func (rect WorldRectangle[T]) MarshalYAML() (interface{}, error) {
+	return rect.Rectangle.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (rect *WorldRectangle[T]) UnmarshalYAML(value *yaml.Node) error {
+	return rect.Rectangle.UnmarshalYAML(value)
+}
+
+// MarshalJSON implements json.Marshaler
+//
+// this has a value receiver so that it is not hidden by the MarshalJSON of the embedded
+// Rectangle when a Set is marshalled by value
+func (set Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.encode())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+// children are added to the set via AddRectangle so are validated in the same way
+func (set *Set[T]) UnmarshalJSON(data []byte) error {
+	var encoded setEncoding[T]
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	return set.decode(encoded)
+}
+
+// MarshalYAML implements yaml.Marshaler
+// see MarshalJSON for why this has a value receiver
+func (set Set[T]) MarshalYAML() (interface{}, error) {
+	return set.encode(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+// children are added to the set via AddRectangle so are validated in the same way
+func (set *Set[T]) UnmarshalYAML(value *yaml.Node) error {
+	var encoded setEncoding[T]
+	if err := value.Decode(&encoded); err != nil {
+		return err
+	}
+
+	return set.decode(encoded)
+}
+
+// encode converts the set into its on disk format
+func (set Set[T]) encode() setEncoding[T] {
+	return setEncoding[T]{
+		ID:       set.ID,
+		X:        set.X,
+		Y:        set.Y,
+		Children: set.Children(),
+		Sets:     set.sets,
+	}
+}
+
+// decode populates the set from its on disk format
+// nested sets are added via AddSet so are validated in the same way
+func (set *Set[T]) decode(encoded setEncoding[T]) error {
+	decoded, err := NewSet(encoded.ID, encoded.X, encoded.Y, encoded.Children)
+	if err != nil {
+		return err
+	}
+
+	for _, nested := range encoded.Sets {
+		if err := decoded.AddSet(nested); err != nil {
+			return err
+		}
+	}
+
+	*set = *decoded
+
+	// nested sets need to point at the set being decoded into rather than the temporary copy
+	for _, nested := range set.sets {
+		nested.parent = set
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (layout *Layout[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(layout.encode())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+// sets are added to the layout via AddSet so are validated in the same way
+func (layout *Layout[T]) UnmarshalJSON(data []byte) error {
+	var encoded layoutEncoding[T]
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	return layout.decode(encoded)
+}
+
+// MarshalYAML implements yaml.Marshaler
+func (layout *Layout[T]) MarshalYAML() (interface{}, error) {
+	return layout.encode(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+// sets are added to the layout via AddSet so are validated in the same way
+func (layout *Layout[T]) UnmarshalYAML(value *yaml.Node) error {
+	var encoded layoutEncoding[T]
+	if err := value.Decode(&encoded); err != nil {
+		return err
+	}
+
+	return layout.decode(encoded)
+}
+
+// encode converts the layout into its on disk format
+func (layout *Layout[T]) encode() layoutEncoding[T] {
+	return layoutEncoding[T]{Sets: layout.sets, Tolerance: layout.tolerance}
+}
+
+// decode populates the layout from its on disk format
+// the sets are replaced but an index enabled on the layout is kept and applied to the new sets,
+// the tolerance of the layout is only replaced if one was encoded
+func (layout *Layout[T]) decode(encoded layoutEncoding[T]) error {
+	decoded, err := NewLayout(encoded.Sets...)
+	if err != nil {
+		return err
+	}
+
+	layout.sets = decoded.sets
+	if layout.indexed {
+		for _, set := range layout.sets {
+			set.EnableIndex(layout.indexCellSize)
+		}
+	}
+
+	if encoded.Tolerance > 0 {
+		layout.SetTolerance(encoded.Tolerance)
+	}
+
+	return nil
+}
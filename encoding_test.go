@@ -0,0 +1,318 @@
+package rekt_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRectangleJSON(t *testing.T) {
+	rect := rekt.NewRectangle("DP-1", 0, 0, 1920, 1080)
+
+	data, err := json.Marshal(rect)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"id":"DP-1","x":0,"y":0,"w":1920,"z":1080}`, string(data))
+
+	var decoded rekt.Rectangle[string]
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, rect, decoded)
+}
+
+func TestRectangleJSONDisplayInfo(t *testing.T) {
+	rect := rekt.NewRectangle("DP-1", 0, 0, 1920, 1080).WithScale(2).WithPhysicalSize(600, 340)
+
+	data, err := json.Marshal(rect)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"id":"DP-1","x":0,"y":0,"w":1920,"z":1080,"scale":2,"width_mm":600,"height_mm":340}`, string(data))
+
+	var decoded rekt.Rectangle[string]
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, rect, decoded)
+
+	data, err = yaml.Marshal(rect)
+	require.Nil(t, err)
+
+	decoded = rekt.Rectangle[string]{}
+	require.Nil(t, yaml.Unmarshal(data, &decoded))
+	require.Equal(t, rect, decoded)
+}
+
+func TestRectangleJSONOrientation(t *testing.T) {
+	rect := rekt.NewRectangle("DP-1", 0, 0, 1920, 1080).
+		WithOrientation(rekt.Orientation{Rotation: rekt.Rotate270, FlipY: true})
+
+	data, err := json.Marshal(rect)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"id":"DP-1","x":0,"y":0,"w":1080,"z":1920,"rotation":270,"flip_y":true}`, string(data))
+
+	var decoded rekt.Rectangle[string]
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, rect, decoded)
+
+	data, err = yaml.Marshal(rect)
+	require.Nil(t, err)
+
+	decoded = rekt.Rectangle[string]{}
+	require.Nil(t, yaml.Unmarshal(data, &decoded))
+	require.Equal(t, rect, decoded)
+
+	bad := `{"id":"DP-1","x":0,"y":0,"w":1080,"z":1920,"rotation":45}`
+	require.ErrorIs(t, json.Unmarshal([]byte(bad), &decoded), rekt.ErrBadRotation)
+}
+
+func TestRectangleYAML(t *testing.T) {
+	rect := rekt.NewRectangle("DP-1", 0, 0, 1920, 1080)
+
+	data, err := yaml.Marshal(rect)
+	require.Nil(t, err)
+	require.YAMLEq(t, "{id: DP-1, x: 0, y: 0, w: 1920, z: 1080}", string(data))
+
+	var decoded rekt.Rectangle[string]
+	require.Nil(t, yaml.Unmarshal(data, &decoded))
+	require.Equal(t, rect, decoded)
+}
+
+func TestRectangleDecodeNotValidated(t *testing.T) {
+	// rectangles are only validated once they are used to build a set so any rectangle can be
+	// round tripped, including the bounds of an empty layout
+	empty, _ := rekt.NewLayout[string]()
+
+	for _, rect := range []rekt.Rectangle[string]{
+		empty.Bounds().Rectangle,
+		rekt.NewRectangle("zero area", 0, 0, 0, 10),
+		rekt.NewRectangle("flipped points", 0, 0, -10, -10),
+	} {
+		t.Run(rect.ID, func(t *testing.T) {
+			data, err := json.Marshal(rect)
+			require.Nil(t, err)
+
+			var decoded rekt.Rectangle[string]
+			require.Nil(t, json.Unmarshal(data, &decoded))
+			require.Equal(t, rect, decoded)
+
+			data, err = yaml.Marshal(rect)
+			require.Nil(t, err)
+
+			decoded = rekt.Rectangle[string]{}
+			require.Nil(t, yaml.Unmarshal(data, &decoded))
+			require.Equal(t, rect, decoded)
+		})
+	}
+}
+
+func TestLeafJSON(t *testing.T) {
+	leaf := rekt.Leaf[string]{
+		Rectangle: rekt.NewRectangle("DP-1", 10, 20, 1930, 1100),
+		Path:      []string{"room", "desk", "DP-1"},
+	}
+
+	data, err := json.Marshal(leaf)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"id":"DP-1","x":10,"y":20,"w":1930,"z":1100,"path":["room","desk","DP-1"]}`, string(data))
+
+	var decoded rekt.Leaf[string]
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, leaf, decoded)
+
+	data, err = yaml.Marshal(leaf)
+	require.Nil(t, err)
+	require.YAMLEq(t, "{id: DP-1, x: 10, y: 20, w: 1930, z: 1100, path: [room, desk, DP-1]}", string(data))
+
+	decoded = rekt.Leaf[string]{}
+	require.Nil(t, yaml.Unmarshal(data, &decoded))
+	require.Equal(t, leaf, decoded)
+}
+
+func TestWorldRectangleJSON(t *testing.T) {
+	rect := rekt.NewRectangle("DP-1", 0, 0, 1920, 1080)
+
+	expected, err := json.Marshal(rect)
+	require.Nil(t, err)
+
+	data, err := json.Marshal(rekt.WorldRectangle[string]{Rectangle: rect})
+	require.Nil(t, err)
+	require.JSONEq(t, string(expected), string(data))
+
+	var local rekt.LocalRectangle[string]
+	require.Nil(t, json.Unmarshal(data, &local))
+	require.Equal(t, rect, local.Rectangle)
+
+	data, err = yaml.Marshal(rekt.WorldRectangle[string]{Rectangle: rect})
+	require.Nil(t, err)
+
+	var decoded rekt.WorldRectangle[string]
+	require.Nil(t, yaml.Unmarshal(data, &decoded))
+	require.Equal(t, rect, decoded.Rectangle)
+}
+
+func encodingSet() *rekt.Set[string] {
+	return layoutSet("desk", 100, 50,
+		rekt.NewRectangle("DP-1", 0, 0, 1920, 1080),
+		rekt.NewRectangle("HDMI-1", 1920, 0, 3840, 1080),
+	)
+}
+
+func TestSetJSON(t *testing.T) {
+	set := encodingSet()
+
+	data, err := json.Marshal(set)
+	require.Nil(t, err)
+	require.JSONEq(t, `{
+		"id": "desk",
+		"x": 100,
+		"y": 50,
+		"children": [
+			{"id": "DP-1", "x": 0, "y": 0, "w": 1920, "z": 1080},
+			{"id": "HDMI-1", "x": 1920, "y": 0, "w": 3840, "z": 1080}
+		]
+	}`, string(data))
+
+	byValue, err := json.Marshal(*set)
+	require.Nil(t, err)
+	require.JSONEq(t, string(data), string(byValue))
+
+	var decoded rekt.Set[string]
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, *set, decoded)
+	require.NotNil(t, decoded.Child("HDMI-1"))
+}
+
+func TestSetYAML(t *testing.T) {
+	set := encodingSet()
+
+	data, err := yaml.Marshal(set)
+	require.Nil(t, err)
+	require.YAMLEq(t, `
+id: desk
+x: 100
+y: 50
+children:
+  - {id: DP-1, x: 0, y: 0, w: 1920, z: 1080}
+  - {id: HDMI-1, x: 1920, y: 0, w: 3840, z: 1080}
+`, string(data))
+
+	var decoded rekt.Set[string]
+	require.Nil(t, yaml.Unmarshal(data, &decoded))
+	require.Equal(t, *set, decoded)
+}
+
+var setDecodeValidationTests = []struct {
+	name     string
+	json     string
+	expected error
+}{
+	{
+		"negative child",
+		`{"id":"set","x":0,"y":0,"children":[{"id":"bad","x":-10,"y":0,"w":10,"z":10}]}`,
+		rekt.ErrNegativePositionInSet,
+	},
+	{
+		"duplicate id",
+		`{"id":"set","x":0,"y":0,"children":[
+			{"id":"dup","x":0,"y":0,"w":10,"z":10},
+			{"id":"dup","x":10,"y":0,"w":20,"z":10}
+		]}`,
+		rekt.ErrDuplicateID,
+	},
+	{
+		"invalid child",
+		`{"id":"set","x":0,"y":0,"children":[{"id":"bad","x":0,"y":0,"w":0,"z":10}]}`,
+		rekt.ErrZoroArea,
+	},
+	{
+		"flipped child",
+		`{"id":"set","x":0,"y":0,"children":[{"id":"bad","x":20,"y":20,"w":10,"z":10}]}`,
+		rekt.ErrBadPoints,
+	},
+}
+
+func TestSetDecodeValidation(t *testing.T) {
+	for _, testCase := range setDecodeValidationTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			var set rekt.Set[string]
+
+			require.ErrorIs(t, json.Unmarshal([]byte(testCase.json), &set), testCase.expected)
+
+			// json is valid yaml so the same input can be used for both
+			require.ErrorIs(t, yaml.Unmarshal([]byte(testCase.json), &set), testCase.expected)
+		})
+	}
+}
+
+func encodingLayout() *rekt.Layout[string] {
+	layout, _ := rekt.NewLayout(
+		layoutSet("desk", 0, 0, rekt.NewRectangle("DP-1", 0, 0, 1920, 1080)),
+		layoutSet("laptop", 1920, 0, rekt.NewRectangle("eDP-1", 0, 0, 1366, 768)),
+	)
+
+	return layout
+}
+
+func TestLayoutJSON(t *testing.T) {
+	layout := encodingLayout()
+
+	data, err := json.Marshal(layout)
+	require.Nil(t, err)
+
+	var decoded rekt.Layout[string]
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, layout.Sets(), decoded.Sets())
+}
+
+func TestLayoutYAML(t *testing.T) {
+	layout := encodingLayout()
+
+	data, err := yaml.Marshal(layout)
+	require.Nil(t, err)
+
+	var decoded rekt.Layout[string]
+	require.Nil(t, yaml.Unmarshal(data, &decoded))
+	require.Equal(t, layout.Sets(), decoded.Sets())
+}
+
+func TestLayoutEncodingKeepsSettings(t *testing.T) {
+	layout, _ := rekt.NewLayout(
+		layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+		layoutSet("b", 102, 0, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+	)
+	layout.SetTolerance(2)
+	require.Nil(t, layout.ValidateConnectivity())
+
+	data, err := json.Marshal(layout)
+	require.Nil(t, err)
+
+	var decoded rekt.Layout[string]
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, 2, decoded.Tolerance())
+	require.Nil(t, decoded.ValidateConnectivity())
+
+	data, err = yaml.Marshal(layout)
+	require.Nil(t, err)
+
+	decoded = rekt.Layout[string]{}
+	require.Nil(t, yaml.Unmarshal(data, &decoded))
+	require.Equal(t, 2, decoded.Tolerance())
+
+	// settings already made on the layout being decoded into are kept
+	target, _ := rekt.NewLayout[string]()
+	target.EnableIndex(0)
+	target.SetTolerance(5)
+	require.Nil(t, json.Unmarshal([]byte(`{"sets":[{"id":"a","x":0,"y":0,"children":[{"id":"a-1","x":0,"y":0,"w":100,"z":100}]}]}`), target))
+	require.Equal(t, 5, target.Tolerance())
+	require.True(t, target.Sets()[0].Indexed())
+}
+
+func TestLayoutDecodeValidation(t *testing.T) {
+	overlapping := `{"sets":[
+		{"id":"a","x":0,"y":0,"children":[{"id":"a-1","x":0,"y":0,"w":100,"z":100}]},
+		{"id":"b","x":50,"y":50,"children":[{"id":"b-1","x":0,"y":0,"w":100,"z":100}]}
+	]}`
+
+	var layout rekt.Layout[string]
+	require.ErrorIs(t, json.Unmarshal([]byte(overlapping), &layout), rekt.ErrSetOverlaps)
+	require.ErrorIs(t, yaml.Unmarshal([]byte(overlapping), &layout), rekt.ErrSetOverlaps)
+	require.Empty(t, layout.Sets())
+}
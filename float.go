@@ -0,0 +1,279 @@
+package rekt
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DefaultEpsilon is the tolerance used by a FloatSet that has not had its Epsilon set
+const DefaultEpsilon = 1e-9
+
+// FloatPoint is the float64 counterpart of Point
+type FloatPoint struct {
+	X float64
+	Y float64
+}
+
+// NewFloatPoint simply fills out the fields of a FloatPoint struct
+func NewFloatPoint(x, y float64) FloatPoint {
+	return FloatPoint{X: x, Y: y}
+}
+
+// FloatRectangle is a parallel implementation of Rectangle using float64 coords
+// it is intended for subpixel positions and normalised (0..1) coordinate spaces
+//
+// as exact equality does not survive float math every comparison takes an epsilon, edges within
+// epsilon of each other are treated as touching and overlaps of epsilon or less are ignored
+type FloatRectangle[T any] struct {
+	ID T
+	// top left
+	X float64
+	Y float64
+	// bottom right
+	W float64
+	Z float64
+}
+
+// FloatEdgeCoordinates is the float64 counterpart of EdgeCoordinates
+type FloatEdgeCoordinates[T any] FloatRectangle[T]
+
+// NewFloatRectangle simply fills out the fields of a FloatRectangle struct
+func NewFloatRectangle[T any](id T, x, y, w, z float64) FloatRectangle[T] {
+	return FloatRectangle[T]{
+		ID: id,
+		X:  x,
+		Y:  y,
+		W:  w,
+		Z:  z,
+	}
+}
+
+// Float converts the rectangle into a FloatRectangle
+// display information is not carried over
+func (rect Rectangle[T]) Float() FloatRectangle[T] {
+	return NewFloatRectangle(rect.ID, float64(rect.X), float64(rect.Y), float64(rect.W), float64(rect.Z))
+}
+
+// Round converts the rectangle into a Rectangle by rounding each of its coords to the nearest int
+func (rect FloatRectangle[T]) Round() Rectangle[T] {
+	return NewRectangle(
+		rect.ID,
+		int(math.Round(rect.X)),
+		int(math.Round(rect.Y)),
+		int(math.Round(rect.W)),
+		int(math.Round(rect.Z)),
+	)
+}
+
+// Offset returns a copy of the rectangle moved by the top left of target
+func (rect FloatRectangle[T]) Offset(target FloatRectangle[T]) FloatRectangle[T] {
+	rect.X += target.X
+	rect.W += target.X
+	rect.Y += target.Y
+	rect.Z += target.Y
+
+	return rect
+}
+
+// Area calculates the area of the rectangle
+func (rect FloatRectangle[T]) Area() float64 {
+	return math.Abs((rect.W - rect.X) * (rect.Z - rect.Y))
+}
+
+// Width returns the calculated width of the rectangle
+func (rect FloatRectangle[T]) Width() float64 {
+	return math.Abs(rect.W - rect.X)
+}
+
+// Height returns the calculated height of the rectangle
+func (rect FloatRectangle[T]) Height() float64 {
+	return math.Abs(rect.Z - rect.Y)
+}
+
+// Overlaps checks if target rectangle overlaps with this one by more than epsilon on both axes
+func (rect FloatRectangle[T]) Overlaps(target FloatRectangle[T], epsilon float64) bool {
+	return spanOverlaps(rect.X, rect.W, target.X, target.W, epsilon) &&
+		spanOverlaps(rect.Y, rect.Z, target.Y, target.Z, epsilon)
+}
+
+// Contains checks if the given point falls within the rectangle
+// the rectangle is treated as half open in the same way as Rectangle.Contains
+func (rect FloatRectangle[T]) Contains(point FloatPoint) bool {
+	return point.X >= rect.X && point.X < rect.W &&
+		point.Y >= rect.Y && point.Y < rect.Z
+}
+
+// OverlappingArea returns (if any) the bounding box of the area where the rectangle overlaps
+// with the target rectangle
+// nil will be returned if the two rectangles do not overlap
+func (rect FloatRectangle[T]) OverlappingArea(target FloatRectangle[T], epsilon float64) *FloatRectangle[T] {
+	if !rect.Overlaps(target, epsilon) {
+		return nil
+	}
+
+	overlap := NewFloatRectangle(
+		target.ID,
+		math.Max(rect.X, target.X),
+		math.Max(rect.Y, target.Y),
+		math.Min(rect.W, target.W),
+		math.Min(rect.Z, target.Z),
+	)
+
+	return &overlap
+}
+
+// Touches returns a slice of sides in which the reciever rectangle touches the target
+// edges within epsilon of each other are treated as touching
+func (rect FloatRectangle[T]) Touches(target FloatRectangle[T], epsilon float64) []Edge {
+	var edges []Edge
+
+	for _, edge := range []Edge{Top, Right, Bottom, Left} {
+		if floatTouches(rect, target, edge, epsilon) {
+			edges = append(edges, edge)
+		}
+	}
+
+	return edges
+}
+
+// TouchCoordinates returns the coordinates of the touch line between two rectangles
+// the line is placed on the edge of the reciever
+// if there is no touch on the given edge nil will be returned
+func (rect FloatRectangle[T]) TouchCoordinates(target FloatRectangle[T], edge Edge, epsilon float64) *FloatEdgeCoordinates[T] {
+	if !floatTouches(rect, target, edge, epsilon) {
+		return nil
+	}
+
+	switch edge {
+	case Top, Bottom:
+		y := rect.Y
+		if edge == Bottom {
+			y = rect.Z
+		}
+
+		return &FloatEdgeCoordinates[T]{
+			ID: target.ID,
+			X:  math.Max(rect.X, target.X),
+			Y:  y,
+			W:  math.Min(rect.W, target.W),
+			Z:  y,
+		}
+
+	default:
+		x := rect.X
+		if edge == Right {
+			x = rect.W
+		}
+
+		return &FloatEdgeCoordinates[T]{
+			ID: target.ID,
+			X:  x,
+			Y:  math.Max(rect.Y, target.Y),
+			W:  x,
+			Z:  math.Min(rect.Z, target.Z),
+		}
+	}
+}
+
+// Validate checks if the rectangle is valid
+// - coords must be finite numbers
+// - X,Y must be top left
+// - W,Z must be bottom right
+// - Width and height must be greater than epsilon
+//
+// problems are reported in the same way as Rectangle.Validate
+func (rect FloatRectangle[T]) Validate(epsilon float64) error {
+	return joinErrors(rect.validationErrors(epsilon))
+}
+
+// validationErrors finds every problem with the rectangle, see Validate
+func (rect FloatRectangle[T]) validationErrors(epsilon float64) []error {
+	var errs []error
+
+	names := []string{"X", "Y", "W", "Z"}
+	for i, n := range []float64{rect.X, rect.Y, rect.W, rect.Z} {
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			errs = append(errs, newValidationError(rect.ID, ErrBadPoints, fmt.Sprintf("%s is %v", names[i], n), names[i]))
+		}
+	}
+
+	// the other checks mean nothing if the coords are not numbers
+	if len(errs) > 0 {
+		return errs
+	}
+
+	var fields, reasons []string
+
+	if math.Abs(rect.Width()) <= epsilon {
+		fields = append(fields, "X", "W")
+		reasons = append(reasons, fmt.Sprintf("width %v is within epsilon of 0", rect.Width()))
+	} else if rect.X > rect.W {
+		errs = append(errs, newValidationError(rect.ID, ErrBadPoints,
+			fmt.Sprintf("X (%v) is greater than W (%v)", rect.X, rect.W), "X", "W"))
+	}
+
+	if math.Abs(rect.Height()) <= epsilon {
+		fields = append(fields, "Y", "Z")
+		reasons = append(reasons, fmt.Sprintf("height %v is within epsilon of 0", rect.Height()))
+	} else if rect.Y > rect.Z {
+		errs = append(errs, newValidationError(rect.ID, ErrBadPoints,
+			fmt.Sprintf("Y (%v) is greater than Z (%v)", rect.Y, rect.Z), "Y", "Z"))
+	}
+
+	if len(fields) > 0 {
+		zero := newValidationError(rect.ID, ErrZoroArea, strings.Join(reasons, " and "), fields...)
+		errs = append([]error{zero}, errs...)
+	}
+
+	return errs
+}
+
+// ToNormalised converts a point in the same space as the rectangle into a normalised point
+// where 0,0 is the top left of the rectangle and 1,1 is the bottom right
+func (rect FloatRectangle[T]) ToNormalised(point FloatPoint) FloatPoint {
+	return NewFloatPoint(
+		(point.X-rect.X)/rect.Width(),
+		(point.Y-rect.Y)/rect.Height(),
+	)
+}
+
+// FromNormalised converts a normalised point back into the same space as the rectangle
+// this is the inverse of ToNormalised
+func (rect FloatRectangle[T]) FromNormalised(point FloatPoint) FloatPoint {
+	return NewFloatPoint(
+		rect.X+point.X*rect.Width(),
+		rect.Y+point.Y*rect.Height(),
+	)
+}
+
+// floatTouches checks if the given edge of rect is within epsilon of either of the parallel edges
+// of target while the two rectangles share more than epsilon along the edge
+func floatTouches[T any](rect, target FloatRectangle[T], edge Edge, epsilon float64) bool {
+	switch edge {
+	case Top:
+		return (near(rect.Y, target.Z, epsilon) || near(rect.Y, target.Y, epsilon)) &&
+			spanOverlaps(rect.X, rect.W, target.X, target.W, epsilon)
+	case Right:
+		return (near(rect.W, target.X, epsilon) || near(rect.W, target.W, epsilon)) &&
+			spanOverlaps(rect.Y, rect.Z, target.Y, target.Z, epsilon)
+	case Bottom:
+		return (near(rect.Z, target.Y, epsilon) || near(rect.Z, target.Z, epsilon)) &&
+			spanOverlaps(rect.X, rect.W, target.X, target.W, epsilon)
+	case Left:
+		return (near(rect.X, target.W, epsilon) || near(rect.X, target.X, epsilon)) &&
+			spanOverlaps(rect.Y, rect.Z, target.Y, target.Z, epsilon)
+	}
+
+	return false
+}
+
+// near checks if a and b are within epsilon of each other
+func near(a, b, epsilon float64) bool {
+	return math.Abs(a-b) <= epsilon
+}
+
+// spanOverlaps checks if the ranges a1..a2 and b1..b2 share more than epsilon
+func spanOverlaps(a1, a2, b1, b2, epsilon float64) bool {
+	return math.Min(a2, b2)-math.Max(a1, b1) > epsilon
+}
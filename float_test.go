@@ -0,0 +1,109 @@
+package rekt_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+// tenth and fifth are variables so that adding them happens at runtime with the usual float
+// error rather than exactly as constants
+var tenth, fifth = 0.1, 0.2
+
+var floatTouchesTests = []struct {
+	name     string
+	rect     rekt.FloatRectangle[string]
+	target   rekt.FloatRectangle[string]
+	expected []rekt.Edge
+}{
+	{
+		"exact",
+		rekt.NewFloatRectangle("a", 0, 0, 0.5, 1),
+		rekt.NewFloatRectangle("b", 0.5, 0, 1, 1),
+		[]rekt.Edge{rekt.Right},
+	},
+	{
+		"float error",
+		rekt.NewFloatRectangle("a", 0, 0, tenth+fifth, 1),
+		rekt.NewFloatRectangle("b", 0.3, 0, 1, 1),
+		[]rekt.Edge{rekt.Right},
+	},
+	{
+		"gap larger than epsilon",
+		rekt.NewFloatRectangle("a", 0, 0, 0.5, 1),
+		rekt.NewFloatRectangle("b", 0.5001, 0, 1, 1),
+		nil,
+	},
+	{
+		"corner within epsilon",
+		rekt.NewFloatRectangle("a", 0, 0, 0.5, 0.5),
+		rekt.NewFloatRectangle("b", 0.5, 0.5+1e-12, 1, 1),
+		nil,
+	},
+	{
+		"below",
+		rekt.NewFloatRectangle("a", 0, 0, 1, 1.0/3),
+		rekt.NewFloatRectangle("b", 0.25, 1-2.0/3, 0.75, 1),
+		[]rekt.Edge{rekt.Bottom},
+	},
+}
+
+func TestFloatRectangleTouches(t *testing.T) {
+	for _, testCase := range floatTouchesTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, testCase.rect.Touches(testCase.target, rekt.DefaultEpsilon))
+		})
+	}
+}
+
+func TestFloatRectangleTouchCoordinates(t *testing.T) {
+	a := rekt.NewFloatRectangle("a", 0, 0, tenth+fifth, 1)
+	b := rekt.NewFloatRectangle("b", 0.3, 0.5, 1, 1.5)
+
+	coords := a.TouchCoordinates(b, rekt.Right, rekt.DefaultEpsilon)
+	require.NotNil(t, coords)
+	require.Equal(t, "b", coords.ID)
+	require.Equal(t, a.W, coords.X)
+	require.Equal(t, 0.5, coords.Y)
+	require.Equal(t, 1.0, coords.Z)
+
+	require.Nil(t, a.TouchCoordinates(b, rekt.Left, rekt.DefaultEpsilon))
+}
+
+func TestFloatRectangleOverlaps(t *testing.T) {
+	a := rekt.NewFloatRectangle("a", 0, 0, tenth+fifth, 1)
+	b := rekt.NewFloatRectangle("b", 0.3, 0, 1, 1)
+	c := rekt.NewFloatRectangle("c", 0.25, 0.25, 1, 1)
+
+	// a overshoots b by a rounding error which should not count as an overlap
+	require.False(t, a.Overlaps(b, rekt.DefaultEpsilon))
+	require.True(t, a.Overlaps(b, 0))
+	require.True(t, a.Overlaps(c, rekt.DefaultEpsilon))
+	require.Nil(t, a.OverlappingArea(b, rekt.DefaultEpsilon))
+	require.Equal(t, &rekt.FloatRectangle[string]{ID: "c", X: 0.25, Y: 0.25, W: a.W, Z: 1}, a.OverlappingArea(c, rekt.DefaultEpsilon))
+}
+
+func TestFloatRectangleValidate(t *testing.T) {
+	require.Nil(t, rekt.NewFloatRectangle("a", 0, 0, 0.5, 0.5).Validate(rekt.DefaultEpsilon))
+	require.ErrorIs(t, rekt.NewFloatRectangle("a", 0, 0, 1e-12, 0.5).Validate(rekt.DefaultEpsilon), rekt.ErrZoroArea)
+	require.ErrorIs(t, rekt.NewFloatRectangle("a", 1, 1, 0, 0).Validate(rekt.DefaultEpsilon), rekt.ErrBadPoints)
+	require.ErrorIs(t, rekt.NewFloatRectangle("a", 0, 0, math.NaN(), 1).Validate(rekt.DefaultEpsilon), rekt.ErrBadPoints)
+	require.ErrorIs(t, rekt.NewFloatRectangle("a", 0, 0, math.Inf(1), 1).Validate(rekt.DefaultEpsilon), rekt.ErrBadPoints)
+}
+
+func TestFloatRectangleConversion(t *testing.T) {
+	rect := rekt.NewRectangle("a", 10, 20, 1930, 1100)
+	require.Equal(t, rekt.NewFloatRectangle("a", 10, 20, 1930, 1100), rect.Float())
+	require.Equal(t, rect, rect.Float().Round())
+	require.Equal(t, rekt.NewRectangle("a", 10, 21, 1930, 1100), rekt.NewFloatRectangle("a", 10.4, 20.5, 1929.6, 1099.5).Round())
+}
+
+func TestFloatRectangleNormalised(t *testing.T) {
+	rect := rekt.NewFloatRectangle("a", 1920, 0, 3840, 1080)
+
+	require.Equal(t, rekt.NewFloatPoint(0.5, 0.25), rect.ToNormalised(rekt.NewFloatPoint(2880, 270)))
+	require.Equal(t, rekt.NewFloatPoint(2880, 270), rect.FromNormalised(rekt.NewFloatPoint(0.5, 0.25)))
+	require.Equal(t, rekt.NewFloatPoint(1920, 0), rect.FromNormalised(rekt.NewFloatPoint(0, 0)))
+}
@@ -0,0 +1,225 @@
+package rekt
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// FloatSet is a parallel implementation of Set using FloatRectangle's
+//
+// the embedded FloatRectangle is in world space and runs from the position of the set to the
+// furthest corner of its children in the same way as Set
+type FloatSet[T comparable] struct {
+	FloatRectangle[T]
+	// Epsilon is the tolerance used when comparing coords
+	// the zero value is treated as DefaultEpsilon
+	Epsilon  float64
+	children []FloatRectangle[T]
+	// index maps the ID of each child to its position in children
+	index map[T]int
+}
+
+// NewFloatSet fills out the fields of the set struct with the given types
+func NewFloatSet[T comparable](id T, x, y float64, children []FloatRectangle[T]) (*FloatSet[T], error) {
+	set := &FloatSet[T]{
+		FloatRectangle: FloatRectangle[T]{
+			ID: id,
+			X:  x,
+			Y:  y,
+		},
+		index: make(map[T]int),
+	}
+
+	var errs []error
+	for _, rect := range children {
+		if err := set.AddRectangle(rect); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := joinErrors(errs); err != nil {
+		return nil, err
+	}
+
+	resizeFloatSetToContent(set)
+
+	return set, nil
+}
+
+// AddRectangle adds a rectangle to the set and recalculates the sets dimensions
+func (set *FloatSet[T]) AddRectangle(rect FloatRectangle[T]) error {
+	errs := rect.validationErrors(set.epsilon())
+
+	var fields, reasons []string
+	if rect.X < -set.epsilon() {
+		fields = append(fields, "X")
+		reasons = append(reasons, fmt.Sprintf("X is %v", rect.X))
+	}
+
+	if rect.Y < -set.epsilon() {
+		fields = append(fields, "Y")
+		reasons = append(reasons, fmt.Sprintf("Y is %v", rect.Y))
+	}
+
+	if len(fields) > 0 {
+		errs = append(errs, newValidationError(rect.ID, ErrNegativePositionInSet, strings.Join(reasons, " and "), fields...))
+	}
+
+	if err := joinErrors(errs); err != nil {
+		return err
+	}
+
+	if _, ok := set.index[rect.ID]; ok {
+		return duplicateIDError(rect.ID)
+	}
+
+	if set.index == nil {
+		set.index = make(map[T]int)
+	}
+
+	set.children = append(set.children, rect)
+	set.index[rect.ID] = len(set.children) - 1
+	resizeFloatSetToContent(set)
+
+	return nil
+}
+
+// RemoveRectangle removes the child with the given id from the set and recalculates the sets
+// dimensions
+func (set *FloatSet[T]) RemoveRectangle(id T) error {
+	i, ok := set.index[id]
+	if !ok {
+		return ErrRectangleNotInSet
+	}
+
+	set.children = append(set.children[:i], set.children[i+1:]...)
+
+	set.index = make(map[T]int, len(set.children))
+	for i, rect := range set.children {
+		set.index[rect.ID] = i
+	}
+
+	resizeFloatSetToContent(set)
+
+	return nil
+}
+
+// Child returns the child rectangle with the given id
+// Coordinates of the child will be relative to the Set space
+// nil will be returned if there is no child with that id
+func (set *FloatSet[T]) Child(id T) *FloatRectangle[T] {
+	i, ok := set.index[id]
+	if !ok {
+		return nil
+	}
+
+	return &set.children[i]
+}
+
+// Children returns the child rectangles of the set
+// Coordinates of the children will be relative to the Set space
+func (set *FloatSet[T]) Children() []FloatRectangle[T] {
+	return set.children
+}
+
+// OffsetChildren returns a copy of the children of the set with their coords offset into world
+// space
+func (set *FloatSet[T]) OffsetChildren() []FloatRectangle[T] {
+	children := make([]FloatRectangle[T], 0, len(set.children))
+
+	for _, rect := range set.children {
+		children = append(children, rect.Offset(set.FloatRectangle))
+	}
+
+	return children
+}
+
+// Move repositions the set within world space
+func (set *FloatSet[T]) Move(x, y float64) {
+	set.X = x
+	set.Y = y
+
+	resizeFloatSetToContent(set)
+}
+
+// ChildAt returns the child rectangle that contains the given point
+// the point is expected to be relative to the Set space
+// nil will be returned if no child contains the point
+func (set *FloatSet[T]) ChildAt(point FloatPoint) *FloatRectangle[T] {
+	for i := range set.children {
+		if set.children[i].Contains(point) {
+			return &set.children[i]
+		}
+	}
+
+	return nil
+}
+
+// OffsetChildAt returns a copy of the child rectangle that contains the given world space point
+// with its coords offset into world space
+// nil will be returned if no child contains the point
+func (set *FloatSet[T]) OffsetChildAt(point FloatPoint) *FloatRectangle[T] {
+	child := set.ChildAt(NewFloatPoint(point.X-set.X, point.Y-set.Y))
+	if child == nil {
+		return nil
+	}
+
+	offset := child.Offset(set.FloatRectangle)
+
+	return &offset
+}
+
+// OverlapsChildren returns the children of the target set that overlap the bounding box of the
+// recievers set
+// both sets are compared in world space and the returned children are in world space
+func (set *FloatSet[T]) OverlapsChildren(target *FloatSet[T]) []FloatRectangle[T] {
+	var overlapping []FloatRectangle[T]
+
+	for _, rect := range target.OffsetChildren() {
+		if set.Overlaps(rect, set.epsilon()) {
+			overlapping = append(overlapping, rect)
+		}
+	}
+
+	return overlapping
+}
+
+// TouchesChildren returns the children of the target set that touch an edge of the bounding box
+// of the recievers set
+// both sets are compared in world space and the returned children are in world space
+func (set *FloatSet[T]) TouchesChildren(target *FloatSet[T]) []FloatRectangle[T] {
+	var touching []FloatRectangle[T]
+
+	for _, rect := range target.OffsetChildren() {
+		if set.Touches(rect, set.epsilon()) != nil {
+			touching = append(touching, rect)
+		}
+	}
+
+	return touching
+}
+
+// epsilon returns the tolerance of the set
+// unlike the Epsilon field this will never be 0
+func (set *FloatSet[T]) epsilon() float64 {
+	if set.Epsilon == 0 {
+		return DefaultEpsilon
+	}
+
+	return set.Epsilon
+}
+
+// resizeFloatSetToContent calculates and sets the bottom right corner of the set based on its
+// children, see resizeSetToContent
+func resizeFloatSetToContent[T comparable](set *FloatSet[T]) {
+	var w, z float64
+
+	for _, rect := range set.children {
+		w = math.Max(w, rect.W)
+		z = math.Max(z, rect.Z)
+	}
+
+	set.W = set.X + w
+	set.Z = set.Y + z
+}
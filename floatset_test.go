@@ -0,0 +1,82 @@
+package rekt_test
+
+import (
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFloatSet(t *testing.T) {
+	set, err := rekt.NewFloatSet("set", 0.5, 0.5, []rekt.FloatRectangle[string]{
+		rekt.NewFloatRectangle("a", 0, 0, 0.25, 0.25),
+		rekt.NewFloatRectangle("b", 0.25, 0, 0.5, 0.25),
+	})
+	require.Nil(t, err)
+	require.Equal(t, rekt.NewFloatRectangle("set", 0.5, 0.5, 1, 0.75), set.FloatRectangle)
+
+	_, err = rekt.NewFloatSet("set", 0, 0, []rekt.FloatRectangle[string]{rekt.NewFloatRectangle("a", -0.1, 0, 0.25, 0.25)})
+	require.ErrorIs(t, err, rekt.ErrNegativePositionInSet)
+
+	// tiny negative positions from float error are tolerated
+	_, err = rekt.NewFloatSet("set", 0, 0, []rekt.FloatRectangle[string]{rekt.NewFloatRectangle("a", -1e-12, 0, 0.25, 0.25)})
+	require.Nil(t, err)
+
+	_, err = rekt.NewFloatSet("set", 0, 0, []rekt.FloatRectangle[string]{
+		rekt.NewFloatRectangle("a", 0, 0, 0.25, 0.25),
+		rekt.NewFloatRectangle("a", 0.25, 0, 0.5, 0.25),
+	})
+	require.ErrorIs(t, err, rekt.ErrDuplicateID)
+
+	empty, err := rekt.NewFloatSet[string]("empty", 1, 1, nil)
+	require.Nil(t, err)
+	require.Equal(t, rekt.NewFloatRectangle("empty", 1, 1, 1, 1), empty.FloatRectangle)
+}
+
+func TestFloatSetRemoveRectangle(t *testing.T) {
+	set, _ := rekt.NewFloatSet("set", 0, 0, []rekt.FloatRectangle[string]{
+		rekt.NewFloatRectangle("a", 0, 0, 0.25, 0.25),
+		rekt.NewFloatRectangle("b", 0.25, 0, 0.5, 0.25),
+	})
+
+	require.Nil(t, set.RemoveRectangle("b"))
+	require.Nil(t, set.Child("b"))
+	require.NotNil(t, set.Child("a"))
+	require.Equal(t, 0.25, set.W)
+	require.ErrorIs(t, set.RemoveRectangle("b"), rekt.ErrRectangleNotInSet)
+}
+
+func TestFloatSetChildAt(t *testing.T) {
+	set, _ := rekt.NewFloatSet("set", 1.5, 0, []rekt.FloatRectangle[string]{
+		rekt.NewFloatRectangle("a", 0, 0, 0.25, 0.25),
+		rekt.NewFloatRectangle("b", 0.25, 0, 0.5, 0.25),
+	})
+
+	require.Equal(t, "b", set.ChildAt(rekt.NewFloatPoint(0.25, 0.1)).ID)
+	require.Nil(t, set.ChildAt(rekt.NewFloatPoint(0.5, 0.1)))
+
+	child := set.OffsetChildAt(rekt.NewFloatPoint(1.6, 0.1))
+	require.Equal(t, &rekt.FloatRectangle[string]{ID: "a", X: 1.5, Y: 0, W: 1.75, Z: 0.25}, child)
+	require.Nil(t, set.OffsetChildAt(rekt.NewFloatPoint(0.1, 0.1)))
+}
+
+func TestFloatSetTouchesChildren(t *testing.T) {
+	// the right set is positioned with a rounding error against the left
+	left, _ := rekt.NewFloatSet("left", 0, 0, []rekt.FloatRectangle[string]{rekt.NewFloatRectangle("left-1", 0, 0, tenth+fifth, 1)})
+	right, _ := rekt.NewFloatSet("right", 0.3, 0.5, []rekt.FloatRectangle[string]{
+		rekt.NewFloatRectangle("right-1", 0, 0, 1, 1),
+		rekt.NewFloatRectangle("right-2", 1, 0, 2, 1),
+	})
+
+	touching := left.TouchesChildren(right)
+	require.Len(t, touching, 1)
+	require.Equal(t, "right-1", touching[0].ID)
+	require.Nil(t, left.OverlapsChildren(right))
+
+	right.Epsilon = 1e-20
+	require.Len(t, right.OverlapsChildren(left), 1)
+
+	right.Move(0.2, 0.5)
+	require.Len(t, left.OverlapsChildren(right), 1)
+	require.Nil(t, left.TouchesChildren(right))
+}
@@ -0,0 +1,229 @@
+package rekt
+
+import (
+	"sort"
+)
+
+// Link describes an edge shared between two nodes of a Graph
+type Link[T any] struct {
+	// To is the index of the node on the other side of the edge
+	To int
+	// ID is the ID of the node on the other side of the edge
+	ID T
+	// Edge is the edge of the node that the link leaves from
+	// the node on the other side is entered via Edge.Opposite()
+	Edge Edge
+	// Coordinates is the section of the edge shared by both nodes
+	Coordinates EdgeCoordinates[T]
+	// Gap is the distance between the edges of the two nodes
+	// this is always 0 unless the graph was built with a tolerance, see NewWorldGraphWithin
+	Gap int
+}
+
+// Node is a single child Rectangle within a Graph
+type Node[T comparable] struct {
+	// Rectangle is the child the node represents
+	// it is a named field rather than embedded so that the methods of Rectangle (including its
+	// json/yaml marshallers) are not promoted onto the node
+	Rectangle Rectangle[T]
+	// Set is the set that the Rectangle belongs to, this will be a nested set if the Rectangle
+	// belongs to one
+	Set *Set[T]
+	// Links holds the links to neighbouring nodes, indexed by the Edge they leave from
+	//
+	//  node.Links[rekt.Right]
+	Links [4][]Link[T]
+}
+
+// Graph is a precomputed adjacency graph of child Rectangles
+// it allows moving between rectangles without having to check every other rectangle for a
+// touching edge each time
+//
+// nodes can be addressed either by their index in Nodes or by the ID of their Rectangle (see
+// Node and IndexOf), IDs only need to be unique within a set so if the same ID is used in more
+// than one set the first node with it is returned
+type Graph[T comparable] struct {
+	Nodes []Node[T]
+	// index maps the ID of each node to its position in Nodes
+	index map[T]int
+	// grid is a spatial index over the nodes, its indexes match those of Nodes
+	grid *Grid[T]
+}
+
+// NewGraph builds the adjacency graph for the children of a single set, including the children
+// of any sets nested within it
+// Coordinates of the nodes will be relative to the Set space
+func NewGraph[T comparable](set *Set[T]) *Graph[T] {
+	var nodes []Node[T]
+
+	for _, owned := range set.localContent() {
+		nodes = append(nodes, Node[T]{Rectangle: owned.rect, Set: owned.set})
+	}
+
+	return newGraph(nodes, 0)
+}
+
+// NewWorldGraph builds the adjacency graph for the children of all the given sets, including the
+// children of any sets nested within them
+// Coordinates of the nodes will be relative to world space
+func NewWorldGraph[T comparable](sets ...*Set[T]) *Graph[T] {
+	return NewWorldGraphWithin(0, sets...)
+}
+
+// Graph builds the world space adjacency graph for every child in the layout
+// children up to the tolerance of the layout apart are linked (see SetTolerance)
+func (layout *Layout[T]) Graph() *Graph[T] {
+	return NewWorldGraphWithin(layout.tolerance, layout.sets...)
+}
+
+// Node returns the node for the Rectangle with the given id
+// nil will be returned if there is no node with that id
+func (graph *Graph[T]) Node(id T) *Node[T] {
+	i := graph.IndexOf(id)
+	if i == -1 {
+		return nil
+	}
+
+	return &graph.Nodes[i]
+}
+
+// IndexOf finds the index of the node for the Rectangle with the given id
+// -1 will be returned if there is no node with that id
+func (graph *Graph[T]) IndexOf(id T) int {
+	if i, ok := graph.index[id]; ok {
+		return i
+	}
+
+	return -1
+}
+
+// NodeAt finds the index of the node that contains the given point
+// -1 will be returned if no node contains the point
+func (graph *Graph[T]) NodeAt(point Point) int {
+	if graph.grid == nil {
+		return -1
+	}
+
+	if found := graph.grid.At(point); len(found) > 0 {
+		return found[0]
+	}
+
+	return -1
+}
+
+// LinkAt finds the link leaving the node via the given edge at the position along that edge
+// (Y for Left/Right, X for Top/Bottom)
+// false will be returned if there is no neighbour at that position
+func (graph *Graph[T]) LinkAt(node int, edge Edge, at int) (Link[T], bool) {
+	if node < 0 || node >= len(graph.Nodes) || edge > Left {
+		return Link[T]{}, false
+	}
+
+	for _, link := range graph.Nodes[node].Links[edge] {
+		if onEdgeSegment(link.Coordinates, edge, at) {
+			return link, true
+		}
+	}
+
+	return Link[T]{}, false
+}
+
+// Components groups the indexes of the nodes that can reach each other
+// groups are ordered by size (largest first) then by the index of their first node
+func (graph *Graph[T]) Components() [][]int {
+	var (
+		components [][]int
+		visited    = make([]bool, len(graph.Nodes))
+	)
+
+	for start := range graph.Nodes {
+		if visited[start] {
+			continue
+		}
+
+		var component []int
+		queue := []int{start}
+		visited[start] = true
+
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			component = append(component, current)
+
+			for _, links := range graph.Nodes[current].Links {
+				for _, link := range links {
+					if !visited[link.To] {
+						visited[link.To] = true
+						queue = append(queue, link.To)
+					}
+				}
+			}
+		}
+
+		sort.Ints(component)
+		components = append(components, component)
+	}
+
+	sort.SliceStable(components, func(i, j int) bool {
+		return len(components[i]) > len(components[j])
+	})
+
+	return components
+}
+
+// newGraph indexes the nodes by id and position then links them together
+// nodes with edges up to tolerance units apart are linked
+func newGraph[T comparable](nodes []Node[T], tolerance int) *Graph[T] {
+	graph := &Graph[T]{
+		Nodes: nodes,
+		index: make(map[T]int, len(nodes)),
+	}
+
+	rects := make([]Rectangle[T], 0, len(nodes))
+	for i, node := range nodes {
+		if _, ok := graph.index[node.Rectangle.ID]; !ok {
+			graph.index[node.Rectangle.ID] = i
+		}
+
+		rects = append(rects, node.Rectangle)
+	}
+
+	graph.grid = NewGrid(0, rects)
+	graph.link(tolerance)
+
+	return graph
+}
+
+// link populates the links between all of the nodes in the graph
+// nodes with edges up to tolerance units apart are linked
+func (graph *Graph[T]) link(tolerance int) {
+	// neighbours sit just outside of each node so the search is grown to cover them
+	grow := tolerance + 1
+
+	for i := range graph.Nodes {
+		rect := graph.Nodes[i].Rectangle
+		area := NewRectangle(rect.ID, rect.X-grow, rect.Y-grow, rect.W+grow, rect.Z+grow)
+
+		for _, edge := range []Edge{Top, Right, Bottom, Left} {
+			for _, j := range graph.grid.Query(area) {
+				if i == j {
+					continue
+				}
+
+				other := graph.Nodes[j].Rectangle
+				coords, gap := rect.neighbourCoordinatesWithin(other, edge, tolerance)
+				if coords == nil {
+					continue
+				}
+
+				graph.Nodes[i].Links[edge] = append(graph.Nodes[i].Links[edge], Link[T]{
+					To:          j,
+					ID:          other.ID,
+					Edge:        edge,
+					Coordinates: *coords,
+					Gap:         gap,
+				})
+			}
+		}
+	}
+}
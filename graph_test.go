@@ -0,0 +1,172 @@
+package rekt_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+func linkIDs(t *testing.T, graph *rekt.Graph[string], links []rekt.Link[string]) []string {
+	var ids []string
+	for _, link := range links {
+		require.Equal(t, link.ID, graph.Nodes[link.To].Rectangle.ID)
+		ids = append(ids, link.ID)
+	}
+
+	return ids
+}
+
+func TestNewGraph(t *testing.T) {
+	set := layoutSet("set", 100, 100,
+		rekt.NewRectangle("left", 0, 0, 100, 100),
+		rekt.NewRectangle("right-top", 100, 0, 200, 50),
+		rekt.NewRectangle("right-bottom", 100, 50, 200, 100),
+		rekt.NewRectangle("below", 0, 100, 200, 150),
+	)
+	graph := rekt.NewGraph(set)
+
+	require.Len(t, graph.Nodes, 4)
+	for i, child := range set.Children() {
+		require.Equal(t, child, graph.Nodes[i].Rectangle)
+		require.Equal(t, set, graph.Nodes[i].Set)
+	}
+
+	left := graph.Nodes[0]
+	require.Nil(t, left.Links[rekt.Top])
+	require.Equal(t, []string{"right-top", "right-bottom"}, linkIDs(t, graph, left.Links[rekt.Right]))
+	require.Equal(t, []string{"below"}, linkIDs(t, graph, left.Links[rekt.Bottom]))
+	require.Nil(t, left.Links[rekt.Left])
+
+	require.Equal(t, rekt.EdgeCoordinates[string]{ID: "right-top", X: 100, Y: 0, W: 100, Z: 50}, left.Links[rekt.Right][0].Coordinates)
+	require.Equal(t, rekt.EdgeCoordinates[string]{ID: "right-bottom", X: 100, Y: 50, W: 100, Z: 100}, left.Links[rekt.Right][1].Coordinates)
+
+	rightTop := graph.Nodes[1]
+	require.Equal(t, []string{"left"}, linkIDs(t, graph, rightTop.Links[rekt.Left]))
+	require.Equal(t, []string{"right-bottom"}, linkIDs(t, graph, rightTop.Links[rekt.Bottom]))
+	require.Equal(t, rekt.Bottom, rightTop.Links[rekt.Bottom][0].Edge)
+
+	below := graph.Nodes[3]
+	require.Equal(t, []string{"left", "right-bottom"}, linkIDs(t, graph, below.Links[rekt.Top]))
+}
+
+func TestNewWorldGraph(t *testing.T) {
+	a := layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100))
+	b := layoutSet("b", 100, 50, rekt.NewRectangle("b-1", 0, 0, 100, 100))
+	c := layoutSet("c", 500, 500, rekt.NewRectangle("c-1", 0, 0, 100, 100))
+	graph := rekt.NewWorldGraph(a, b, c)
+
+	require.Len(t, graph.Nodes, 3)
+	require.Equal(t, b.OffsetChildren()[0], graph.Nodes[1].Rectangle)
+	require.Equal(t, b, graph.Nodes[1].Set)
+
+	require.Equal(t, []string{"b-1"}, linkIDs(t, graph, graph.Nodes[0].Links[rekt.Right]))
+	require.Equal(t, rekt.EdgeCoordinates[string]{ID: "b-1", X: 100, Y: 50, W: 100, Z: 100}, graph.Nodes[0].Links[rekt.Right][0].Coordinates)
+	require.Equal(t, []string{"a-1"}, linkIDs(t, graph, graph.Nodes[1].Links[rekt.Left]))
+
+	for _, links := range graph.Nodes[2].Links {
+		require.Nil(t, links)
+	}
+
+	layout, _ := rekt.NewLayout(a, b, c)
+	require.Equal(t, graph, layout.Graph())
+}
+
+func TestGraphNodeByID(t *testing.T) {
+	a := layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100))
+	b := layoutSet("b", 100, 50,
+		rekt.NewRectangle("b-1", 0, 0, 100, 100),
+		rekt.NewRectangle("a-1", 100, 0, 200, 100),
+	)
+	graph := rekt.NewWorldGraph(a, b)
+
+	require.Equal(t, 1, graph.IndexOf("b-1"))
+	require.Equal(t, -1, graph.IndexOf("missing"))
+	require.Nil(t, graph.Node("missing"))
+
+	node := graph.Node("b-1")
+	require.NotNil(t, node)
+	require.Equal(t, b, node.Set)
+	require.Equal(t, "a-1", node.Links[rekt.Left][0].ID)
+
+	// ids only need to be unique within a set, the first node wins
+	require.Equal(t, a, graph.Node("a-1").Set)
+}
+
+func TestGraphNodeJSON(t *testing.T) {
+	set := layoutSet("set", 0, 0,
+		rekt.NewRectangle("left", 0, 0, 100, 100),
+		rekt.NewRectangle("right", 100, 0, 200, 100),
+	)
+	node := rekt.NewGraph(set).Node("left")
+
+	data, err := json.Marshal(node)
+	require.Nil(t, err)
+
+	var decoded map[string]json.RawMessage
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	require.Contains(t, decoded, "Rectangle")
+	require.Contains(t, decoded, "Set")
+	require.Contains(t, decoded, "Links")
+}
+
+func TestGraphNodeAt(t *testing.T) {
+	a := layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100))
+	b := layoutSet("b", 100, 50, rekt.NewRectangle("b-1", 0, 0, 100, 100))
+	graph := rekt.NewWorldGraph(a, b)
+
+	require.Equal(t, 0, graph.NodeAt(rekt.NewPoint(0, 0)))
+	require.Equal(t, 1, graph.NodeAt(rekt.NewPoint(100, 50)))
+	require.Equal(t, -1, graph.NodeAt(rekt.NewPoint(150, 0)))
+}
+
+var graphLinkAtTests = []struct {
+	name     string
+	node     int
+	edge     rekt.Edge
+	at       int
+	expected string
+}{
+	{"top of shared segment", 0, rekt.Right, 50, "b-1"},
+	{"bottom of shared segment", 0, rekt.Right, 99, "b-1"},
+	{"above shared segment", 0, rekt.Right, 49, ""},
+	{"back again", 1, rekt.Left, 60, "a-1"},
+	{"below shared segment", 1, rekt.Left, 100, ""},
+	{"no links", 0, rekt.Top, 10, ""},
+	{"bad node", 5, rekt.Right, 50, ""},
+	{"bad edge", 0, rekt.Edge(100), 50, ""},
+}
+
+func TestGraphLinkAt(t *testing.T) {
+	a := layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100))
+	b := layoutSet("b", 100, 50, rekt.NewRectangle("b-1", 0, 0, 100, 100))
+	graph := rekt.NewWorldGraph(a, b)
+
+	for _, testCase := range graphLinkAtTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			link, ok := graph.LinkAt(testCase.node, testCase.edge, testCase.at)
+			if testCase.expected == "" {
+				require.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			require.Equal(t, testCase.expected, graph.Nodes[link.To].Rectangle.ID)
+		})
+	}
+}
+
+func TestGraphComponents(t *testing.T) {
+	graph := rekt.NewWorldGraph(
+		layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+		layoutSet("b", 500, 0,
+			rekt.NewRectangle("b-1", 0, 0, 100, 100),
+			rekt.NewRectangle("b-2", 100, 0, 200, 100),
+		),
+		layoutSet("c", 100, 0, rekt.NewRectangle("c-1", 0, 0, 100, 100)),
+		layoutSet("d", 1000, 0, rekt.NewRectangle("d-1", 0, 0, 100, 100)),
+	)
+
+	require.Equal(t, [][]int{{0, 3}, {1, 2}, {4}}, graph.Components())
+}
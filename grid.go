@@ -0,0 +1,286 @@
+package rekt
+
+import (
+	"sort"
+)
+
+// Grid is a uniform grid spatial index over a group of rectangles
+//
+// space is split into square cells and each rectangle is recorded against every cell it covers,
+// queries then only need to check the rectangles in the cells they cover rather than every
+// rectangle in the index
+//
+// results are returned as indexes into the slice of rectangles the grid was built from, in
+// ascending order
+type Grid[T any] struct {
+	cellSize int
+	// auto is set when the cell size was picked by the grid, it will then be picked again if
+	// much larger rectangles are added later on
+	auto  bool
+	cells map[gridCell][]int
+	rects []Rectangle[T]
+}
+
+// gridRegrowFactor is how many times larger than the cell size a rectangle added to an auto sized
+// grid has to be before the cell size is picked again
+const gridRegrowFactor = 4
+
+// gridCell identifies a single cell within a Grid
+type gridCell struct {
+	x int
+	y int
+}
+
+// NewGrid builds a grid index over the given rectangles
+//
+// cellSize should be roughly the size of the rectangles being indexed, if it is less than 1 a
+// size will be picked based on the average size of the rectangles, the size is then picked again
+// whenever a rectangle much larger than the cells is added so a grid started empty (or with only
+// small rectangles) does not end up with large rectangles spread over huge numbers of cells
+func NewGrid[T any](cellSize int, rects []Rectangle[T]) *Grid[T] {
+	grid := &Grid[T]{
+		cellSize: cellSize,
+		auto:     cellSize < 1,
+	}
+	grid.reset(rects)
+
+	return grid
+}
+
+// CellSize returns the size of the cells used by the grid
+func (grid *Grid[T]) CellSize() int {
+	return grid.cellSize
+}
+
+// Len returns the number of rectangles in the grid
+func (grid *Grid[T]) Len() int {
+	return len(grid.rects)
+}
+
+// Insert adds a rectangle to the grid and returns its index
+func (grid *Grid[T]) Insert(rect Rectangle[T]) int {
+	i := len(grid.rects)
+	grid.rects = append(grid.rects, rect)
+
+	if grid.outgrown(rect) {
+		grid.reset(grid.rects)
+	} else {
+		grid.link(i)
+	}
+
+	return i
+}
+
+// Update replaces the rectangle at index i and moves it to the cells it now covers
+func (grid *Grid[T]) Update(i int, rect Rectangle[T]) {
+	grid.unlink(i)
+	grid.rects[i] = rect
+
+	if grid.outgrown(rect) {
+		grid.reset(grid.rects)
+	} else {
+		grid.link(i)
+	}
+}
+
+// Remove takes the rectangle at index i out of the grid
+// the indexes of the rectangles after it are shifted down by one to match removing it from the
+// slice the grid was built from
+func (grid *Grid[T]) Remove(i int) {
+	grid.unlink(i)
+
+	for j := i + 1; j < len(grid.rects); j++ {
+		grid.relink(j, j-1)
+	}
+
+	grid.rects = append(grid.rects[:i], grid.rects[i+1:]...)
+}
+
+// insertAt adds a rectangle to the grid at index i
+// the indexes of the rectangles from i onwards are shifted up by one to match inserting it into
+// the slice the grid was built from
+func (grid *Grid[T]) insertAt(i int, rect Rectangle[T]) {
+	for j := len(grid.rects) - 1; j >= i; j-- {
+		grid.relink(j, j+1)
+	}
+
+	grid.rects = append(grid.rects, rect)
+	copy(grid.rects[i+1:], grid.rects[i:])
+	grid.rects[i] = rect
+
+	if grid.outgrown(rect) {
+		grid.reset(grid.rects)
+	} else {
+		grid.link(i)
+	}
+}
+
+// reset rebuilds the grid over the given rectangles, picking the cell size again if it is auto
+// sized
+func (grid *Grid[T]) reset(rects []Rectangle[T]) {
+	if grid.auto {
+		grid.cellSize = autoCellSize(rects)
+	}
+
+	// the grid keeps its own copy so it is not changed along with the slice it was built from
+	grid.rects = append([]Rectangle[T](nil), rects...)
+	grid.cells = make(map[gridCell][]int)
+
+	for i := range grid.rects {
+		grid.link(i)
+	}
+}
+
+// outgrown checks if the rectangle is large enough compared to the cells of an auto sized grid
+// that the cell size should be picked again
+func (grid *Grid[T]) outgrown(rect Rectangle[T]) bool {
+	return grid.auto && max(rect.Width(), rect.Height()) > grid.cellSize*gridRegrowFactor
+}
+
+// link records the rectangle at index i against every cell it covers
+func (grid *Grid[T]) link(i int) {
+	grid.eachCell(grid.rects[i], func(cell gridCell) {
+		grid.cells[cell] = append(grid.cells[cell], i)
+	})
+}
+
+// unlink removes the rectangle at index i from every cell it covers
+func (grid *Grid[T]) unlink(i int) {
+	grid.eachCell(grid.rects[i], func(cell gridCell) {
+		entries := grid.cells[cell]
+		for j, entry := range entries {
+			if entry == i {
+				entries = append(entries[:j], entries[j+1:]...)
+				break
+			}
+		}
+
+		if len(entries) == 0 {
+			delete(grid.cells, cell)
+		} else {
+			grid.cells[cell] = entries
+		}
+	})
+}
+
+// relink changes the index that the rectangle at index from is recorded under to to
+func (grid *Grid[T]) relink(from, to int) {
+	grid.eachCell(grid.rects[from], func(cell gridCell) {
+		entries := grid.cells[cell]
+		for j, entry := range entries {
+			if entry == from {
+				entries[j] = to
+				break
+			}
+		}
+	})
+}
+
+// eachCell calls fn for every cell covered by the rectangle
+func (grid *Grid[T]) eachCell(rect Rectangle[T], fn func(gridCell)) {
+	minX, minY, maxX, maxY := grid.cellRange(rect.X, rect.Y, rect.W-1, rect.Z-1)
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			fn(gridCell{x, y})
+		}
+	}
+}
+
+// At returns the indexes of the rectangles that contain the point
+func (grid *Grid[T]) At(point Point) []int {
+	return grid.find(point.X, point.Y, point.X, point.Y, func(rect Rectangle[T]) bool {
+		return rect.Contains(point)
+	})
+}
+
+// Query returns the indexes of the rectangles that overlap the area
+func (grid *Grid[T]) Query(area Rectangle[T]) []int {
+	return grid.find(area.X, area.Y, area.W-1, area.Z-1, func(rect Rectangle[T]) bool {
+		return area.Overlaps(rect)
+	})
+}
+
+// Touching returns the indexes of the rectangles that have an edge touching the given rectangle
+// this follows the same rules as Rectangle.Touches
+func (grid *Grid[T]) Touching(target Rectangle[T]) []int {
+	return grid.TouchingWithin(target, 0)
+}
+
+// TouchingWithin returns the indexes of the rectangles that have an edge up to tolerance units
+// from an edge of the given rectangle
+// this follows the same rules as Rectangle.TouchesWithin
+func (grid *Grid[T]) TouchingWithin(target Rectangle[T], tolerance int) []int {
+	// touching rectangles sit just outside of the target so the search is grown to cover them
+	grow := tolerance + 1
+
+	return grid.find(target.X-grow, target.Y-grow, target.W+tolerance, target.Z+tolerance, func(rect Rectangle[T]) bool {
+		return target.TouchesWithin(rect, tolerance) != nil
+	})
+}
+
+// find checks every rectangle in the cells covering the inclusive range x1,y1 -> x2,y2 against
+// the match func and returns the unique indexes of those that match
+func (grid *Grid[T]) find(x1, y1, x2, y2 int, match func(Rectangle[T]) bool) []int {
+	var candidates []int
+
+	minX, minY, maxX, maxY := grid.cellRange(x1, y1, x2, y2)
+	if grid.occupiedWithin(maxX-minX+1, maxY-minY+1) {
+		// the range covers more cells than are in use so only the cells in use are checked
+		for cell, entries := range grid.cells {
+			if cell.x >= minX && cell.x <= maxX && cell.y >= minY && cell.y <= maxY {
+				candidates = append(candidates, entries...)
+			}
+		}
+	} else {
+		for y := minY; y <= maxY; y++ {
+			for x := minX; x <= maxX; x++ {
+				candidates = append(candidates, grid.cells[gridCell{x, y}]...)
+			}
+		}
+	}
+
+	// rectangles that cover multiple cells will show up more than once
+	sort.Ints(candidates)
+
+	var found []int
+	for j, i := range candidates {
+		if j > 0 && candidates[j-1] == i {
+			continue
+		}
+
+		if match(grid.rects[i]) {
+			found = append(found, i)
+		}
+	}
+
+	return found
+}
+
+// occupiedWithin checks if there are fewer cells in use than there are in a range of width by
+// height cells
+func (grid *Grid[T]) occupiedWithin(width, height int) bool {
+	// dividing rather than multiplying keeps huge ranges from overflowing
+	return width > len(grid.cells) || height > len(grid.cells)/width
+}
+
+// cellRange returns the inclusive range of cells that cover the inclusive range x1,y1 -> x2,y2
+func (grid *Grid[T]) cellRange(x1, y1, x2, y2 int) (minX, minY, maxX, maxY int) {
+	return floorDiv(x1, grid.cellSize),
+		floorDiv(y1, grid.cellSize),
+		floorDiv(max(x1, x2), grid.cellSize),
+		floorDiv(max(y1, y2), grid.cellSize)
+}
+
+// autoCellSize picks a cell size based on the average of the longest side of each rectangle
+func autoCellSize[T any](rects []Rectangle[T]) int {
+	if len(rects) == 0 {
+		return 1
+	}
+
+	var total int
+	for _, rect := range rects {
+		total += max(rect.Width(), rect.Height())
+	}
+
+	return max(1, total/len(rects))
+}
@@ -0,0 +1,327 @@
+package rekt_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+// videoWall builds a set of size*size tiles of 1920x1080
+func videoWall(size int) *rekt.Set[string] {
+	var tiles []rekt.Rectangle[string]
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			tiles = append(tiles, rekt.NewRectangle(
+				fmt.Sprintf("tile-%d-%d", x, y),
+				x*1920,
+				y*1080,
+				(x+1)*1920,
+				(y+1)*1080,
+			))
+		}
+	}
+
+	set, _ := rekt.NewSet("wall", 0, 0, tiles)
+
+	return set
+}
+
+func randomRectangles(random *rand.Rand, count, span int) []rekt.Rectangle[string] {
+	var rects []rekt.Rectangle[string]
+
+	for i := 0; i < count; i++ {
+		x, y := random.Intn(span)-span/2, random.Intn(span)-span/2
+		rects = append(rects, rekt.NewRectangle(
+			fmt.Sprint(i),
+			x,
+			y,
+			x+1+random.Intn(span/4),
+			y+1+random.Intn(span/4),
+		))
+	}
+
+	return rects
+}
+
+func TestGridMatchesLinearScan(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	for _, cellSize := range []int{0, 1, 7, 50, 1000} {
+		t.Run(fmt.Sprint(cellSize), func(t *testing.T) {
+			rects := randomRectangles(random, 100, 200)
+			grid := rekt.NewGrid(cellSize, rects)
+			require.Equal(t, len(rects), grid.Len())
+			require.GreaterOrEqual(t, grid.CellSize(), 1)
+
+			for i := 0; i < 200; i++ {
+				point := rekt.NewPoint(random.Intn(240)-120, random.Intn(240)-120)
+				area := randomRectangles(random, 1, 200)[0]
+
+				var expectedAt, expectedQuery, expectedTouching []int
+				for j, rect := range rects {
+					if rect.Contains(point) {
+						expectedAt = append(expectedAt, j)
+					}
+					if area.Overlaps(rect) {
+						expectedQuery = append(expectedQuery, j)
+					}
+					if area.Touches(rect) != nil {
+						expectedTouching = append(expectedTouching, j)
+					}
+				}
+
+				require.Equal(t, expectedAt, grid.At(point))
+				require.Equal(t, expectedQuery, grid.Query(area))
+				require.Equal(t, expectedTouching, grid.Touching(area))
+			}
+		})
+	}
+}
+
+func TestGridInsert(t *testing.T) {
+	grid := rekt.NewGrid[string](10, nil)
+
+	require.Equal(t, 0, grid.Insert(rekt.NewRectangle("a", -15, -15, -5, -5)))
+	require.Equal(t, 1, grid.Insert(rekt.NewRectangle("b", -5, -5, 5, 5)))
+
+	require.Equal(t, []int{0}, grid.At(rekt.NewPoint(-10, -10)))
+	require.Equal(t, []int{1}, grid.At(rekt.NewPoint(-5, -5)))
+	require.Equal(t, []int{0}, grid.Touching(rekt.NewRectangle("query", -5, -15, 0, -10)))
+	require.Nil(t, grid.At(rekt.NewPoint(5, 5)))
+}
+
+func TestGridUpdateRemove(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+
+	rects := randomRectangles(random, 50, 200)
+	grid := rekt.NewGrid(20, rects)
+
+	for i := 0; i < 100; i++ {
+		j := random.Intn(len(rects))
+		if i%2 == 0 {
+			rects[j] = randomRectangles(random, 1, 200)[0]
+			grid.Update(j, rects[j])
+		} else {
+			rects = append(rects[:j], rects[j+1:]...)
+			grid.Remove(j)
+			rects = append(rects, randomRectangles(random, 1, 200)[0])
+			require.Equal(t, len(rects)-1, grid.Insert(rects[len(rects)-1]))
+		}
+
+		require.Equal(t, len(rects), grid.Len())
+
+		area := randomRectangles(random, 1, 200)[0]
+		var expected []int
+		for k, rect := range rects {
+			if area.Overlaps(rect) {
+				expected = append(expected, k)
+			}
+		}
+		require.Equal(t, expected, grid.Query(area))
+	}
+}
+
+func TestSetIndexMatchesLinearScan(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+
+	plain := videoWall(6)
+	indexed := videoWall(6)
+	indexed.EnableIndex(0)
+	require.True(t, indexed.Indexed())
+	require.False(t, plain.Indexed())
+
+	mutate := func(set *rekt.Set[string]) {
+		require.Nil(t, set.RemoveRectangle("tile-2-2"))
+		require.Nil(t, set.MoveRectangle("tile-5-5", 12000, 7000))
+		require.Nil(t, set.UpdateRectangle("tile-0-0", rekt.NewRectangle("tile-0-0", 0, 0, 960, 540)))
+		require.Nil(t, set.AddRectangle(rekt.NewRectangle("extra", 11520, 0, 13440, 1080)))
+		require.Nil(t, set.RemoveRectangle("tile-1-0"))
+		require.Nil(t, set.UpdateRectangle("tile-3-3", rekt.NewRectangle("renamed", 5760, 3240, 7680, 4320)))
+	}
+	mutate(plain)
+	mutate(indexed)
+
+	for i := 0; i < 500; i++ {
+		point := rekt.NewPoint(random.Intn(14000), random.Intn(8500))
+		require.Equal(t, plain.ChildAt(point), indexed.ChildAt(point), point)
+
+		probe, _ := rekt.NewSet("probe", 0, 0, []rekt.Rectangle[string]{
+			rekt.NewRectangle("probe", point.X, point.Y, point.X+1+random.Intn(3000), point.Y+1+random.Intn(3000)),
+		})
+		require.Equal(t, probe.OverlapsChildren(*plain), probe.OverlapsChildren(*indexed))
+		require.Equal(t, probe.TouchesChildren(*plain), probe.TouchesChildren(*indexed))
+	}
+
+	indexed.DisableIndex()
+	require.False(t, indexed.Indexed())
+}
+
+func TestLayoutEnableIndex(t *testing.T) {
+	wall := videoWall(4)
+	laptop := layoutSet("laptop", -1366, 0, rekt.NewRectangle("eDP-1", 0, 0, 1366, 768))
+	layout, _ := rekt.NewLayout(wall)
+
+	layout.EnableIndex(0)
+	require.True(t, wall.Indexed())
+
+	require.Nil(t, layout.AddSet(laptop))
+	require.True(t, laptop.Indexed())
+
+	set, child := layout.ChildAt(rekt.NewPoint(-10, 10))
+	require.Equal(t, "laptop", set.ID)
+	require.Equal(t, "eDP-1", child.Rectangle.ID)
+
+	query := world("query", -10, 1000, 10, 1100)
+	require.Equal(t, []string{"tile-0-0", "tile-0-1"}, worldIDs(layout.Overlapping(query)))
+	require.Equal(t, []string{"eDP-1"}, worldIDs(layout.Touching(world("query", -1366, 768, -100, 800))))
+
+	overlapping := layoutSet("overlapping", 3000, 2000, rekt.NewRectangle("overlapping-1", 0, 0, 10, 10))
+	require.ErrorIs(t, layout.AddSet(overlapping), rekt.ErrSetOverlaps)
+}
+
+func TestSetIndexEnabledWhileEmpty(t *testing.T) {
+	plain, _ := rekt.NewSet[string]("wall", 0, 0, nil)
+	indexed, _ := rekt.NewSet[string]("wall", 0, 0, nil)
+	indexed.EnableIndex(0)
+
+	layout, _ := rekt.NewLayout[string]()
+	layout.EnableIndex(0)
+	nested, _ := rekt.NewSet[string]("nested", 0, 0, nil)
+	require.Nil(t, layout.AddSet(nested))
+
+	for i := 0; i < 3; i++ {
+		display := rekt.NewRectangle(fmt.Sprint(i), i*3840, 0, (i+1)*3840, 2160)
+		require.Nil(t, plain.AddRectangle(display))
+		require.Nil(t, indexed.AddRectangle(display))
+		require.Nil(t, nested.AddRectangle(display))
+	}
+
+	for _, point := range []rekt.Point{rekt.NewPoint(10, 10), rekt.NewPoint(4000, 2000), rekt.NewPoint(12000, 10)} {
+		require.Equal(t, plain.ChildAt(point), indexed.ChildAt(point), point)
+		require.Equal(t, plain.ChildAt(point), nested.ChildAt(point), point)
+	}
+}
+
+func TestGridInsertRepicksCellSize(t *testing.T) {
+	grid := rekt.NewGrid[string](0, nil)
+	require.Equal(t, 1, grid.CellSize())
+
+	// a cell size picked while empty would spread each display over millions of cells
+	grid.Insert(rekt.NewRectangle("a", 0, 0, 3840, 2160))
+	require.GreaterOrEqual(t, grid.CellSize(), 2160)
+
+	grid.Insert(rekt.NewRectangle("b", 3840, 0, 7680, 2160))
+	require.Equal(t, []int{1}, grid.At(rekt.NewPoint(4000, 10)))
+
+	// grids given a cell size keep it
+	fixed := rekt.NewGrid[string](10, nil)
+	fixed.Insert(rekt.NewRectangle("a", 0, 0, 3840, 2160))
+	require.Equal(t, 10, fixed.CellSize())
+}
+
+func TestGridQueryLargeArea(t *testing.T) {
+	grid := rekt.NewGrid(1, []rekt.Rectangle[string]{
+		rekt.NewRectangle("a", 0, 0, 2, 2),
+		rekt.NewRectangle("b", 100, 100, 102, 102),
+	})
+
+	// only the cells in use are checked so a query far larger than the content stays cheap
+	area := rekt.NewRectangle("area", -1<<30, -1<<30, 1<<30, 1<<30)
+	require.Equal(t, []int{0, 1}, grid.Query(area))
+	require.Equal(t, []int{1}, grid.Query(rekt.NewRectangle("area", 50, 50, 1<<30, 1<<30)))
+}
+
+func benchmarkSetChildAt(b *testing.B, indexed bool) {
+	wall := videoWall(20)
+	if indexed {
+		wall.EnableIndex(0)
+	}
+
+	point := rekt.NewPoint(wall.W/2+1, wall.Z/2+1)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wall.ChildAt(point)
+	}
+}
+
+func BenchmarkSetChildAtLinear(b *testing.B)  { benchmarkSetChildAt(b, false) }
+func BenchmarkSetChildAtIndexed(b *testing.B) { benchmarkSetChildAt(b, true) }
+
+func benchmarkSetOverlapsChildren(b *testing.B, indexed bool) {
+	wall := videoWall(20)
+	if indexed {
+		wall.EnableIndex(0)
+	}
+
+	probe, _ := rekt.NewSet("probe", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("probe", 0, 0, 2000, 2000),
+	})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		probe.OverlapsChildren(*wall)
+	}
+}
+
+func BenchmarkSetOverlapsChildrenLinear(b *testing.B)  { benchmarkSetOverlapsChildren(b, false) }
+func BenchmarkSetOverlapsChildrenIndexed(b *testing.B) { benchmarkSetOverlapsChildren(b, true) }
+
+func benchmarkSetTouchesChildren(b *testing.B, indexed bool) {
+	wall := videoWall(20)
+	if indexed {
+		wall.EnableIndex(0)
+	}
+
+	probe, _ := rekt.NewSet("probe", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("probe", 0, 0, 1920, 1080),
+	})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		probe.TouchesChildren(*wall)
+	}
+}
+
+func BenchmarkSetTouchesChildrenLinear(b *testing.B)  { benchmarkSetTouchesChildren(b, false) }
+func BenchmarkSetTouchesChildrenIndexed(b *testing.B) { benchmarkSetTouchesChildren(b, true) }
+
+func benchmarkSetMoveRectangle(b *testing.B, indexed bool) {
+	wall := videoWall(20)
+	if indexed {
+		wall.EnableIndex(0)
+	}
+
+	// swap the tile between two free spots to the right of the wall so every move is valid
+	x := []int{wall.W, wall.W + 1920}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wall.MoveRectangle("tile-10-10", x[i%2], 0)
+	}
+}
+
+func BenchmarkSetMoveRectangleLinear(b *testing.B)  { benchmarkSetMoveRectangle(b, false) }
+func BenchmarkSetMoveRectangleIndexed(b *testing.B) { benchmarkSetMoveRectangle(b, true) }
+
+func benchmarkSetRemoveAddRectangle(b *testing.B, indexed bool) {
+	wall := videoWall(20)
+	if indexed {
+		wall.EnableIndex(0)
+	}
+
+	tile := *wall.Child("tile-10-10")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wall.RemoveRectangle(tile.ID)
+		wall.AddRectangle(tile)
+	}
+}
+
+func BenchmarkSetRemoveAddRectangleLinear(b *testing.B)  { benchmarkSetRemoveAddRectangle(b, false) }
+func BenchmarkSetRemoveAddRectangleIndexed(b *testing.B) { benchmarkSetRemoveAddRectangle(b, true) }
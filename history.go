@@ -0,0 +1,282 @@
+package rekt
+
+import (
+	"errors"
+)
+
+var (
+	ErrNothingToUndo = errors.New("there are no changes to undo")
+	ErrNothingToRedo = errors.New("there are no changes to redo")
+)
+
+// History records the changes made to a Set so that they can be undone and redone
+//
+// changes must be made through the History rather than directly on the Set for them to be
+// recorded, changes made directly to the set are not tracked and may stop recorded changes from
+// being undone cleanly
+type History[T comparable] struct {
+	set  *Set[T]
+	undo []historyEntry
+	redo []historyEntry
+	// group collects the steps of an open Group, nil when no group is open
+	group *historyEntry
+}
+
+// historyEntry is a single undoable change, a group of changes is recorded as one entry
+type historyEntry struct {
+	steps []historyStep
+}
+
+// historyStep is a single reversible operation on a set
+type historyStep struct {
+	undo func() error
+	redo func() error
+}
+
+// historySnapshot holds the state of a set that the steps of a history can change so that it can
+// be put back if an entry fails part way through
+type historySnapshot[T comparable] struct {
+	x, y     int
+	children []Rectangle[T]
+}
+
+// NewHistory starts recording changes to the set
+func NewHistory[T comparable](set *Set[T]) *History[T] {
+	return &History[T]{set: set}
+}
+
+// Set returns the set that the history is recording
+func (history *History[T]) Set() *Set[T] {
+	return history.set
+}
+
+// AddRectangle adds a rectangle to the set, see Set.AddRectangle
+func (history *History[T]) AddRectangle(rect Rectangle[T]) error {
+	return history.apply(historyStep{
+		undo: func() error { return history.set.RemoveRectangle(rect.ID) },
+		redo: func() error { return history.set.AddRectangle(rect) },
+	})
+}
+
+// RemoveRectangle removes the child with the given id from the set, see Set.RemoveRectangle
+// undoing the removal puts the child back in the same position amongst the other children
+func (history *History[T]) RemoveRectangle(id T) error {
+	i := history.set.indexOf(id)
+	if i == -1 {
+		return ErrRectangleNotInSet
+	}
+
+	removed := history.set.children[i]
+
+	return history.apply(historyStep{
+		undo: func() error { return history.set.insertRectangle(i, removed) },
+		redo: func() error { return history.set.RemoveRectangle(id) },
+	})
+}
+
+// UpdateRectangle replaces the child with the given id, see Set.UpdateRectangle
+func (history *History[T]) UpdateRectangle(id T, rect Rectangle[T]) error {
+	child := history.set.Child(id)
+	if child == nil {
+		return ErrRectangleNotInSet
+	}
+
+	previous := *child
+
+	return history.apply(historyStep{
+		undo: func() error { return history.set.UpdateRectangle(rect.ID, previous) },
+		redo: func() error { return history.set.UpdateRectangle(id, rect) },
+	})
+}
+
+// MoveRectangle repositions the child with the given id, see Set.MoveRectangle
+func (history *History[T]) MoveRectangle(id T, x, y int) error {
+	child := history.set.Child(id)
+	if child == nil {
+		return ErrRectangleNotInSet
+	}
+
+	previousX, previousY := child.X, child.Y
+
+	return history.apply(historyStep{
+		undo: func() error { return history.set.MoveRectangle(id, previousX, previousY) },
+		redo: func() error { return history.set.MoveRectangle(id, x, y) },
+	})
+}
+
+// ResizeRectangle changes the size of the child with the given id, see Set.ResizeRectangle
+func (history *History[T]) ResizeRectangle(id T, width, height int) error {
+	child := history.set.Child(id)
+	if child == nil {
+		return ErrRectangleNotInSet
+	}
+
+	previousWidth, previousHeight := child.Width(), child.Height()
+
+	return history.apply(historyStep{
+		undo: func() error { return history.set.ResizeRectangle(id, previousWidth, previousHeight) },
+		redo: func() error { return history.set.ResizeRectangle(id, width, height) },
+	})
+}
+
+// Move repositions the set within world space, see Set.Move
+func (history *History[T]) Move(x, y int) error {
+	previousX, previousY := history.set.X, history.set.Y
+
+	return history.apply(historyStep{
+		undo: func() error { history.set.Move(previousX, previousY); return nil },
+		redo: func() error { history.set.Move(x, y); return nil },
+	})
+}
+
+// Group records every change made within fn as a single entry that is undone and redone together
+//
+// if fn returns an error the set is put back the way it was before fn was called and nothing is
+// recorded
+// groups can be nested, the changes of an inner group become part of the outer one
+func (history *History[T]) Group(fn func() error) error {
+	outer := history.group
+	history.group = &historyEntry{}
+
+	err := history.atomic(fn)
+	group := history.group
+	history.group = outer
+
+	if err != nil {
+		return err
+	}
+
+	if len(group.steps) > 0 {
+		history.record(group.steps...)
+	}
+
+	return nil
+}
+
+// Undo reverts the most recent change (or group of changes)
+//
+// an entry is undone as a whole, if any of its steps fail (e.g. the set was changed directly in a
+// way that conflicts with it) the set is put back the way it was before Undo was called and the
+// entry is left in place
+func (history *History[T]) Undo() error {
+	if len(history.undo) == 0 {
+		return ErrNothingToUndo
+	}
+
+	entry := history.undo[len(history.undo)-1]
+	if err := history.atomic(entry.revert); err != nil {
+		return err
+	}
+
+	history.undo = history.undo[:len(history.undo)-1]
+	history.redo = append(history.redo, entry)
+
+	return nil
+}
+
+// Redo reapplies the most recently undone change (or group of changes)
+// in the same way as Undo an entry is redone as a whole or not at all
+func (history *History[T]) Redo() error {
+	if len(history.redo) == 0 {
+		return ErrNothingToRedo
+	}
+
+	entry := history.redo[len(history.redo)-1]
+	if err := history.atomic(entry.replay); err != nil {
+		return err
+	}
+
+	history.redo = history.redo[:len(history.redo)-1]
+	history.undo = append(history.undo, entry)
+
+	return nil
+}
+
+// CanUndo checks if there are any changes to undo
+func (history *History[T]) CanUndo() bool {
+	return len(history.undo) > 0
+}
+
+// CanRedo checks if there are any undone changes to redo
+func (history *History[T]) CanRedo() bool {
+	return len(history.redo) > 0
+}
+
+// Clear forgets every recorded change
+func (history *History[T]) Clear() {
+	history.undo = nil
+	history.redo = nil
+}
+
+// apply makes the change and records it if it was successful
+func (history *History[T]) apply(step historyStep) error {
+	if err := step.redo(); err != nil {
+		return err
+	}
+
+	history.record(step)
+
+	return nil
+}
+
+// atomic runs fn and puts the set back the way it was before fn was called if it fails
+func (history *History[T]) atomic(fn func() error) error {
+	snapshot := history.snapshot()
+
+	if err := fn(); err != nil {
+		history.restore(snapshot)
+		return err
+	}
+
+	return nil
+}
+
+// snapshot captures the current state of the set
+func (history *History[T]) snapshot() historySnapshot[T] {
+	return historySnapshot[T]{
+		x:        history.set.X,
+		y:        history.set.Y,
+		children: append([]Rectangle[T](nil), history.set.children...),
+	}
+}
+
+// restore puts the set back to the state captured in the snapshot
+func (history *History[T]) restore(snapshot historySnapshot[T]) {
+	history.set.children = snapshot.children
+	reindexSet(history.set)
+	history.set.Move(snapshot.x, snapshot.y)
+}
+
+// record adds the steps to the open group, or as a new entry if there is no open group
+// recording a new change clears anything that could be redone
+func (history *History[T]) record(steps ...historyStep) {
+	if history.group != nil {
+		history.group.steps = append(history.group.steps, steps...)
+		return
+	}
+
+	history.undo = append(history.undo, historyEntry{steps: steps})
+	history.redo = nil
+}
+
+// revert undoes the steps of the entry in reverse order
+func (entry *historyEntry) revert() error {
+	for i := len(entry.steps) - 1; i >= 0; i-- {
+		if err := entry.steps[i].undo(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replay redoes the steps of the entry in order
+func (entry *historyEntry) replay() error {
+	for _, step := range entry.steps {
+		if err := step.redo(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
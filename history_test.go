@@ -0,0 +1,209 @@
+package rekt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+func historySet() *rekt.Set[string] {
+	return layoutSet("set", 0, 0,
+		rekt.NewRectangle("rect-1", 0, 0, 10, 10),
+		rekt.NewRectangle("rect-2", 10, 0, 20, 10),
+		rekt.NewRectangle("rect-3", 20, 0, 30, 10),
+	)
+}
+
+var historyTests = []struct {
+	name   string
+	change func(history *rekt.History[string]) error
+}{
+	{"add", func(history *rekt.History[string]) error {
+		return history.AddRectangle(rekt.NewRectangle("rect-4", 0, 10, 10, 20))
+	}},
+	{"remove", func(history *rekt.History[string]) error {
+		return history.RemoveRectangle("rect-2")
+	}},
+	{"update", func(history *rekt.History[string]) error {
+		return history.UpdateRectangle("rect-1", rekt.NewRectangle("renamed", 0, 10, 5, 15))
+	}},
+	{"move rectangle", func(history *rekt.History[string]) error {
+		return history.MoveRectangle("rect-3", 40, 40)
+	}},
+	{"resize rectangle", func(history *rekt.History[string]) error {
+		return history.ResizeRectangle("rect-3", 50, 50)
+	}},
+	{"move set", func(history *rekt.History[string]) error {
+		return history.Move(100, 200)
+	}},
+}
+
+func TestHistoryUndoRedo(t *testing.T) {
+	for _, testCase := range historyTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			set := historySet()
+			history := rekt.NewHistory(set)
+			original, originalChildren := set.Rectangle, append([]rekt.Rectangle[string](nil), set.Children()...)
+
+			require.Nil(t, testCase.change(history))
+			changed, changedChildren := set.Rectangle, append([]rekt.Rectangle[string](nil), set.Children()...)
+			require.True(t, history.CanUndo())
+			require.False(t, history.CanRedo())
+
+			require.Nil(t, history.Undo())
+			require.Equal(t, original, set.Rectangle)
+			require.Equal(t, originalChildren, set.Children())
+			require.False(t, history.CanUndo())
+			require.True(t, history.CanRedo())
+
+			require.Nil(t, history.Redo())
+			require.Equal(t, changed, set.Rectangle)
+			require.Equal(t, changedChildren, set.Children())
+		})
+	}
+}
+
+func TestHistoryNothingToUndo(t *testing.T) {
+	history := rekt.NewHistory(historySet())
+
+	require.ErrorIs(t, history.Undo(), rekt.ErrNothingToUndo)
+	require.ErrorIs(t, history.Redo(), rekt.ErrNothingToRedo)
+}
+
+func TestHistoryFailedChangeNotRecorded(t *testing.T) {
+	history := rekt.NewHistory(historySet())
+
+	require.ErrorIs(t, history.AddRectangle(rekt.NewRectangle("rect-1", 0, 10, 10, 20)), rekt.ErrDuplicateID)
+	require.ErrorIs(t, history.RemoveRectangle("missing"), rekt.ErrRectangleNotInSet)
+	require.ErrorIs(t, history.MoveRectangle("rect-1", -1, 0), rekt.ErrNegativePositionInSet)
+	require.False(t, history.CanUndo())
+}
+
+func TestHistoryNewChangeClearsRedo(t *testing.T) {
+	history := rekt.NewHistory(historySet())
+
+	require.Nil(t, history.MoveRectangle("rect-1", 0, 10))
+	require.Nil(t, history.Undo())
+	require.True(t, history.CanRedo())
+
+	require.Nil(t, history.MoveRectangle("rect-2", 10, 10))
+	require.False(t, history.CanRedo())
+}
+
+func TestHistoryUndoOrder(t *testing.T) {
+	set := historySet()
+	history := rekt.NewHistory(set)
+
+	require.Nil(t, history.MoveRectangle("rect-1", 0, 10))
+	require.Nil(t, history.MoveRectangle("rect-1", 0, 20))
+
+	require.Nil(t, history.Undo())
+	require.Equal(t, rekt.NewRectangle("rect-1", 0, 10, 10, 20), *set.Child("rect-1"))
+
+	require.Nil(t, history.Undo())
+	require.Equal(t, rekt.NewRectangle("rect-1", 0, 0, 10, 10), *set.Child("rect-1"))
+}
+
+func TestHistoryGroup(t *testing.T) {
+	set := historySet()
+	original := append([]rekt.Rectangle[string](nil), set.Children()...)
+	history := rekt.NewHistory(set)
+
+	err := history.Group(func() error {
+		if err := history.RemoveRectangle("rect-1"); err != nil {
+			return err
+		}
+
+		// nested groups become part of the outer group
+		return history.Group(func() error {
+			return history.MoveRectangle("rect-3", 0, 0)
+		})
+	})
+	require.Nil(t, err)
+	require.Len(t, set.Children(), 2)
+
+	require.Nil(t, history.Undo())
+	require.Equal(t, original, set.Children())
+	require.False(t, history.CanUndo())
+
+	require.Nil(t, history.Redo())
+	require.Len(t, set.Children(), 2)
+	require.Equal(t, rekt.NewRectangle("rect-3", 0, 0, 10, 10), *set.Child("rect-3"))
+}
+
+func TestHistoryGroupRollback(t *testing.T) {
+	set := historySet()
+	original := append([]rekt.Rectangle[string](nil), set.Children()...)
+	history := rekt.NewHistory(set)
+	failed := errors.New("failed")
+
+	err := history.Group(func() error {
+		require.Nil(t, history.RemoveRectangle("rect-1"))
+		require.Nil(t, history.MoveRectangle("rect-2", 50, 50))
+
+		return failed
+	})
+
+	require.ErrorIs(t, err, failed)
+	require.Equal(t, original, set.Children())
+	require.False(t, history.CanUndo())
+}
+
+func TestHistoryUndoGroupPartialFailure(t *testing.T) {
+	set := historySet()
+	history := rekt.NewHistory(set)
+
+	require.Nil(t, history.Group(func() error {
+		if err := history.AddRectangle(rekt.NewRectangle("rect-4", 0, 10, 10, 20)); err != nil {
+			return err
+		}
+
+		return history.MoveRectangle("rect-1", 40, 40)
+	}))
+
+	// removing rect-4 directly means the add can no longer be undone, the move is undone first
+	// and has to be put back when the add fails
+	require.Nil(t, set.RemoveRectangle("rect-4"))
+	changed, changedChildren := set.Rectangle, append([]rekt.Rectangle[string](nil), set.Children()...)
+
+	require.ErrorIs(t, history.Undo(), rekt.ErrRectangleNotInSet)
+	require.Equal(t, changed, set.Rectangle)
+	require.Equal(t, changedChildren, set.Children())
+	require.Equal(t, 40, set.Child("rect-1").X)
+	require.True(t, history.CanUndo())
+	require.False(t, history.CanRedo())
+
+	// once the conflict is gone the whole group can be undone
+	require.Nil(t, set.AddRectangle(rekt.NewRectangle("rect-4", 0, 10, 10, 20)))
+	require.Nil(t, history.Undo())
+	require.Equal(t, 0, set.Child("rect-1").X)
+	require.Nil(t, set.Child("rect-4"))
+}
+
+func TestHistoryRedoGroupPartialFailure(t *testing.T) {
+	set := historySet()
+	history := rekt.NewHistory(set)
+
+	require.Nil(t, history.Group(func() error {
+		if err := history.AddRectangle(rekt.NewRectangle("rect-4", 0, 10, 10, 20)); err != nil {
+			return err
+		}
+
+		return history.MoveRectangle("rect-1", 40, 40)
+	}))
+	require.Nil(t, history.Undo())
+
+	// removing rect-1 directly means the move can no longer be redone, the add is redone first
+	// and has to be taken back out when the move fails
+	require.Nil(t, set.RemoveRectangle("rect-1"))
+	undone, undoneChildren := set.Rectangle, append([]rekt.Rectangle[string](nil), set.Children()...)
+
+	require.ErrorIs(t, history.Redo(), rekt.ErrRectangleNotInSet)
+	require.Equal(t, undone, set.Rectangle)
+	require.Equal(t, undoneChildren, set.Children())
+	require.Nil(t, set.Child("rect-4"))
+	require.False(t, history.CanUndo())
+	require.True(t, history.CanRedo())
+}
@@ -0,0 +1,255 @@
+package rekt
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrSetOverlaps    = errors.New("set overlaps with another set in the layout")
+	ErrSetInLayout    = errors.New("set is already part of the layout")
+	ErrSetNotInLayout = errors.New("set is not part of the layout")
+)
+
+// Layout defines the whole virtual screen space made up of multiple Sets
+// Sets within a layout are positioned in world space and are not allowed to overlap, this
+// includes the content of any sets nested within them
+//
+// the layout holds on to the sets it is given rather than taking a copy of them, this means that
+// changes made directly to a set (Set.Move, Set.AddRectangle, History, Batch etc) show up in the
+// layout straight away but the layout cannot stop them from making sets overlap, Validate should
+// be called after changing a set in this way
+//
+// AddSet and MoveSet check the whole layout so they will refuse to make any change while sets
+// in the layout overlap, other than a move that fixes the overlap
+type Layout[T comparable] struct {
+	sets []*Set[T]
+	// indexCellSize is the cell size used to index sets as they are added, see EnableIndex
+	indexCellSize int
+	indexed       bool
+	// tolerance is the size of gap between children that is still treated as touching, see
+	// SetTolerance
+	tolerance int
+}
+
+// NewLayout creates a layout from the given sets
+func NewLayout[T comparable](sets ...*Set[T]) (*Layout[T], error) {
+	layout := &Layout[T]{}
+
+	for _, set := range sets {
+		if err := layout.AddSet(set); err != nil {
+			return nil, err
+		}
+	}
+
+	return layout, nil
+}
+
+// AddSet adds a set to the layout
+// the set must not overlap any of the sets already in the layout
+func (layout *Layout[T]) AddSet(set *Set[T]) error {
+	if layout.indexOf(set) != -1 {
+		return ErrSetInLayout
+	}
+
+	if other := layout.overlappedBy(set); other != nil {
+		return setOverlapsError(set, other)
+	}
+
+	// the sets already in the layout may have been changed directly since they were added
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+
+	if layout.indexed {
+		set.EnableIndex(layout.indexCellSize)
+	}
+
+	layout.sets = append(layout.sets, set)
+
+	return nil
+}
+
+// RemoveSet removes the set from the layout
+func (layout *Layout[T]) RemoveSet(set *Set[T]) error {
+	i := layout.indexOf(set)
+	if i == -1 {
+		return ErrSetNotInLayout
+	}
+
+	layout.sets = append(layout.sets[:i], layout.sets[i+1:]...)
+
+	return nil
+}
+
+// MoveSet repositions a set within the layout
+// if the set would overlap another set in its new position, or the layout would still have
+// overlapping sets after the move (see Validate), it will not be moved
+func (layout *Layout[T]) MoveSet(set *Set[T], x, y int) error {
+	if layout.indexOf(set) == -1 {
+		return ErrSetNotInLayout
+	}
+
+	prevX, prevY := set.X, set.Y
+	set.Move(x, y)
+
+	if other := layout.overlappedBy(set); other != nil {
+		set.Move(prevX, prevY)
+		return setOverlapsError(set, other)
+	}
+
+	if err := layout.Validate(); err != nil {
+		set.Move(prevX, prevY)
+		return err
+	}
+
+	return nil
+}
+
+// Validate checks that none of the sets in the layout overlap each other
+// this only needs to be called after changing sets directly, see the Layout docs
+//
+// each overlapping pair of sets is reported as a *ValidationError wrapping ErrSetOverlaps, if
+// there is more than one they are combined with errors.Join
+func (layout *Layout[T]) Validate() error {
+	var errs []error
+
+	for i, set := range layout.sets {
+		for _, other := range layout.sets[i+1:] {
+			if setsOverlap(set, other) {
+				errs = append(errs, setOverlapsError(set, other))
+			}
+		}
+	}
+
+	return joinErrors(errs)
+}
+
+// Sets returns a copy of the slice of sets in the layout
+func (layout *Layout[T]) Sets() []*Set[T] {
+	return append([]*Set[T](nil), layout.sets...)
+}
+
+// Overlapping returns the children of all sets in the layout (including nested sets) that
+// overlap the world space rectangle
+func (layout *Layout[T]) Overlapping(area WorldRectangle[T]) []WorldRectangle[T] {
+	var overlapping []WorldRectangle[T]
+
+	for _, set := range layout.sets {
+		for _, owned := range set.contentOverlapping(area.Rectangle) {
+			overlapping = append(overlapping, WorldRectangle[T]{owned.rect})
+		}
+	}
+
+	return overlapping
+}
+
+// Touching returns the children of all sets in the layout (including nested sets) that touch an
+// edge of the world space rectangle
+func (layout *Layout[T]) Touching(target WorldRectangle[T]) []WorldRectangle[T] {
+	var touching []WorldRectangle[T]
+
+	for _, set := range layout.sets {
+		for _, owned := range set.contentTouchingWithin(target.Rectangle, 0) {
+			touching = append(touching, WorldRectangle[T]{owned.rect})
+		}
+	}
+
+	return touching
+}
+
+// ChildAt finds the child Rectangle (and the Set it belongs to) that contains the world space
+// point, the Set will be the nested set that owns the child if it is found in a nested set
+func (layout *Layout[T]) ChildAt(point Point) (*Set[T], *WorldRectangle[T]) {
+	set, child := offsetChildAt(layout.sets, point)
+	if child == nil {
+		return nil, nil
+	}
+
+	return set, &WorldRectangle[T]{*child}
+}
+
+// Bounds calculates the world space bounding box of every child in the layout
+// an empty layout will return a zero sized Rectangle
+func (layout *Layout[T]) Bounds() WorldRectangle[T] {
+	return WorldRectangle[T]{outerBounds(placedBounds(layout.sets))}
+}
+
+// EnableIndex enables a spatial index on every set in the layout, including sets that are added
+// to it later
+// see Set.EnableIndex for more details
+func (layout *Layout[T]) EnableIndex(cellSize int) {
+	layout.indexed = true
+	layout.indexCellSize = cellSize
+
+	for _, set := range layout.sets {
+		set.EnableIndex(cellSize)
+	}
+}
+
+// SetTolerance sets the size of gap between the edges of children that is still treated as
+// touching by ValidateConnectivity, Graph, ResolveCrossing and ResolveCrossingWith
+// this allows for displays that are reported slightly out of alignment, the default is 0
+func (layout *Layout[T]) SetTolerance(tolerance int) {
+	layout.tolerance = max(0, tolerance)
+}
+
+// Tolerance returns the size of gap between children that is treated as touching
+// see SetTolerance
+func (layout *Layout[T]) Tolerance() int {
+	return layout.tolerance
+}
+
+// ResolveCrossing works out where the cursor ends up after moving by dx,dy from the world space
+// point using the tolerance of the layout, see ResolveCrossing for more details
+func (layout *Layout[T]) ResolveCrossing(from Point, dx, dy int, mapping Mapping) *Crossing[T] {
+	return ResolveCrossingWithin(layout.sets, from, dx, dy, mapping, WarpBlock, layout.tolerance)
+}
+
+// ResolveCrossingWith works out where the cursor ends up after moving by dx,dy from the world
+// space point using the tolerance of the layout, see ResolveCrossingWith for more details
+func (layout *Layout[T]) ResolveCrossingWith(from Point, dx, dy int, mapping Mapping, policy WarpPolicy) *Crossing[T] {
+	return ResolveCrossingWithin(layout.sets, from, dx, dy, mapping, policy, layout.tolerance)
+}
+
+// indexOf finds the position of the set in the layout
+// -1 will be returned if it is not found
+func (layout *Layout[T]) indexOf(set *Set[T]) int {
+	for i, s := range layout.sets {
+		if s == set {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// overlappedBy finds the first other set in the layout with content that overlaps the content of
+// the set
+// nil will be returned if the set does not overlap any other set
+func (layout *Layout[T]) overlappedBy(set *Set[T]) *Set[T] {
+	for _, other := range layout.sets {
+		if other != set && setsOverlap(set, other) {
+			return other
+		}
+	}
+
+	return nil
+}
+
+// setOverlapsError describes a set that overlaps another set in the layout
+func setOverlapsError[T comparable](set, other *Set[T]) error {
+	return newValidationError(set.ID, ErrSetOverlaps, fmt.Sprintf("overlaps set %v", other.ID), "X", "Y")
+}
+
+// setsOverlap checks if any of the content of the two sets (including nested sets) overlap in
+// world space
+func setsOverlap[T comparable](a, b *Set[T]) bool {
+	for _, owned := range a.content() {
+		if len(b.contentOverlapping(owned.rect)) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
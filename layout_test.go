@@ -0,0 +1,285 @@
+package rekt_test
+
+import (
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+func layoutSet(id string, x, y int, children ...rekt.Rectangle[string]) *rekt.Set[string] {
+	set, err := rekt.NewSet(id, x, y, children)
+	if err != nil {
+		panic(err)
+	}
+
+	return set
+}
+
+func TestNewLayout(t *testing.T) {
+	left := layoutSet("left", 0, 0, rekt.NewRectangle("left-1", 0, 0, 100, 100))
+	right := layoutSet("right", 100, 0, rekt.NewRectangle("right-1", 0, 0, 100, 100))
+	overlapping := layoutSet("overlapping", 50, 50, rekt.NewRectangle("overlapping-1", 0, 0, 100, 100))
+
+	layout, err := rekt.NewLayout(left, right)
+	require.Nil(t, err)
+	require.Equal(t, []*rekt.Set[string]{left, right}, layout.Sets())
+
+	layout, err = rekt.NewLayout(left, right, overlapping)
+	require.ErrorIs(t, err, rekt.ErrSetOverlaps)
+	require.Nil(t, layout)
+
+	layout, err = rekt.NewLayout(left, left)
+	require.ErrorIs(t, err, rekt.ErrSetInLayout)
+	require.Nil(t, layout)
+}
+
+func TestLayoutAddSet(t *testing.T) {
+	layout, _ := rekt.NewLayout[string]()
+
+	// the bounding boxes of these sets overlap but their children do not
+	lShape := layoutSet("l-shape", 0, 0,
+		rekt.NewRectangle("l-shape-1", 0, 0, 10, 100),
+		rekt.NewRectangle("l-shape-2", 10, 90, 100, 100),
+	)
+	nested := layoutSet("nested", 10, 0, rekt.NewRectangle("nested-1", 0, 0, 90, 90))
+
+	require.Nil(t, layout.AddSet(lShape))
+	require.Nil(t, layout.AddSet(nested))
+	require.ErrorIs(t, layout.AddSet(nested), rekt.ErrSetInLayout)
+
+	overlapping := layoutSet("overlapping", 0, 0, rekt.NewRectangle("overlapping-1", 5, 5, 15, 15))
+	require.ErrorIs(t, layout.AddSet(overlapping), rekt.ErrSetOverlaps)
+	require.Len(t, layout.Sets(), 2)
+}
+
+func TestLayoutRemoveSet(t *testing.T) {
+	left := layoutSet("left", 0, 0, rekt.NewRectangle("left-1", 0, 0, 100, 100))
+	right := layoutSet("right", 100, 0, rekt.NewRectangle("right-1", 0, 0, 100, 100))
+	layout, _ := rekt.NewLayout(left, right)
+
+	require.Nil(t, layout.RemoveSet(left))
+	require.Equal(t, []*rekt.Set[string]{right}, layout.Sets())
+	require.ErrorIs(t, layout.RemoveSet(left), rekt.ErrSetNotInLayout)
+}
+
+func TestLayoutMoveSet(t *testing.T) {
+	left := layoutSet("left", 0, 0, rekt.NewRectangle("left-1", 0, 0, 100, 100))
+	right := layoutSet("right", 100, 0, rekt.NewRectangle("right-1", 0, 0, 100, 100))
+	outside := layoutSet("outside", 0, 0, rekt.NewRectangle("outside-1", 0, 0, 100, 100))
+	layout, _ := rekt.NewLayout(left, right)
+
+	require.ErrorIs(t, layout.MoveSet(right, 50, 50), rekt.ErrSetOverlaps)
+	require.Equal(t, 100, right.X)
+	require.Equal(t, 0, right.Y)
+
+	require.Nil(t, layout.MoveSet(right, 0, 100))
+	require.Equal(t, 0, right.X)
+	require.Equal(t, 100, right.Y)
+
+	require.ErrorIs(t, layout.MoveSet(outside, 500, 500), rekt.ErrSetNotInLayout)
+}
+
+func TestLayoutValidate(t *testing.T) {
+	left := layoutSet("left", 0, 0, rekt.NewRectangle("left-1", 0, 0, 100, 100))
+	right := layoutSet("right", 100, 0, rekt.NewRectangle("right-1", 0, 0, 100, 100))
+	below := layoutSet("below", 0, 100, rekt.NewRectangle("below-1", 0, 0, 100, 100))
+	extra := layoutSet("extra", 500, 500, rekt.NewRectangle("extra-1", 0, 0, 100, 100))
+	layout, _ := rekt.NewLayout(left, right, below)
+	require.Nil(t, layout.Validate())
+
+	// changes made directly to the sets are not seen until the layout is validated
+	right.Move(50, 0)
+	require.Nil(t, right.AddRectangle(rekt.NewRectangle("right-2", 0, 100, 100, 200)))
+
+	err := layout.Validate()
+	require.ErrorIs(t, err, rekt.ErrSetOverlaps)
+
+	var validation *rekt.ValidationError[string]
+	require.ErrorAs(t, err, &validation)
+	require.Equal(t, "left", validation.ID)
+	require.Equal(t, "overlaps set right", validation.Reason)
+
+	// nothing else can change until the overlap is fixed
+	require.ErrorIs(t, layout.AddSet(extra), rekt.ErrSetOverlaps)
+	require.ErrorIs(t, layout.MoveSet(below, 0, 300), rekt.ErrSetOverlaps)
+	require.Equal(t, 100, below.Y)
+
+	require.Nil(t, right.RemoveRectangle("right-2"))
+	require.Nil(t, layout.MoveSet(right, 100, 0))
+	require.Nil(t, layout.Validate())
+	require.Nil(t, layout.AddSet(extra))
+}
+
+func layoutQuerySets() *rekt.Layout[string] {
+	layout, _ := rekt.NewLayout(
+		layoutSet("left", 0, 0,
+			rekt.NewRectangle("left-1", 0, 0, 100, 100),
+			rekt.NewRectangle("left-2", 0, 100, 100, 150),
+		),
+		layoutSet("right", 100, 20, rekt.NewRectangle("right-1", 0, 0, 100, 100)),
+	)
+
+	return layout
+}
+
+var layoutOverlappingTests = []struct {
+	name     string
+	rect     rekt.WorldRectangle[string]
+	expected []string
+}{
+	{"nothing", world("query", 500, 500, 600, 600), nil},
+	{"single", world("query", 10, 10, 20, 20), []string{"left-1"}},
+	{"across sets", world("query", 90, 90, 110, 110), []string{"left-1", "left-2", "right-1"}},
+	{"world space", world("query", 150, 0, 160, 20), nil},
+}
+
+func TestLayoutOverlapping(t *testing.T) {
+	layout := layoutQuerySets()
+
+	for _, testCase := range layoutOverlappingTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			var ids []string
+			for _, child := range layout.Overlapping(testCase.rect) {
+				ids = append(ids, child.Rectangle.ID)
+			}
+
+			require.Equal(t, testCase.expected, ids)
+		})
+	}
+}
+
+var layoutTouchingTests = []struct {
+	name     string
+	rect     rekt.WorldRectangle[string]
+	expected []string
+}{
+	{"nothing", world("query", 500, 500, 600, 600), nil},
+	{"below", world("query", 0, 150, 10, 160), []string{"left-2"}},
+	{"world space", world("query", 150, 120, 160, 130), []string{"right-1"}},
+}
+
+func TestLayoutTouching(t *testing.T) {
+	layout := layoutQuerySets()
+
+	for _, testCase := range layoutTouchingTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			var ids []string
+			for _, child := range layout.Touching(testCase.rect) {
+				ids = append(ids, child.Rectangle.ID)
+			}
+
+			require.Equal(t, testCase.expected, ids)
+		})
+	}
+}
+
+var layoutChildAtTests = []struct {
+	point         rekt.Point
+	expectedSet   string
+	expectedChild string
+}{
+	{rekt.NewPoint(0, 0), "left", "left-1"},
+	{rekt.NewPoint(0, 100), "left", "left-2"},
+	{rekt.NewPoint(100, 20), "right", "right-1"},
+	{rekt.NewPoint(100, 0), "", ""},
+	{rekt.NewPoint(200, 50), "", ""},
+}
+
+func TestLayoutChildAt(t *testing.T) {
+	layout := layoutQuerySets()
+
+	for _, testCase := range layoutChildAtTests {
+		t.Run(testCase.expectedChild, func(t *testing.T) {
+			set, child := layout.ChildAt(testCase.point)
+			if testCase.expectedChild == "" {
+				require.Nil(t, set)
+				require.Nil(t, child)
+				return
+			}
+
+			require.Equal(t, testCase.expectedSet, set.ID)
+			require.Equal(t, testCase.expectedChild, child.Rectangle.ID)
+			require.True(t, child.Rectangle.Contains(testCase.point))
+		})
+	}
+}
+
+func TestLayoutBounds(t *testing.T) {
+	empty, _ := rekt.NewLayout[string]()
+	require.Equal(t, rekt.WorldRectangle[string]{}, empty.Bounds())
+
+	bounds := layoutQuerySets().Bounds().Rectangle
+	require.Equal(t, 0, bounds.X)
+	require.Equal(t, 0, bounds.Y)
+	require.Equal(t, 200, bounds.W)
+	require.Equal(t, 150, bounds.Z)
+}
+
+func TestLayoutResolveCrossing(t *testing.T) {
+	layout := layoutQuerySets()
+
+	crossing := layout.ResolveCrossing(rekt.NewPoint(95, 50), 10, 0, rekt.MapAbsolute)
+	require.NotNil(t, crossing)
+	require.Equal(t, "right-1", crossing.Child.ID)
+	require.Equal(t, rekt.NewPoint(105, 50), crossing.Point)
+}
+
+// nestedLayout builds a layout with a desk set that has a monitor nested within it and a laptop
+// set to the right of the monitor
+func nestedLayout() (*rekt.Layout[string], *rekt.Set[string], *rekt.Set[string]) {
+	desk := layoutSet("desk", 0, 0, rekt.NewRectangle("desk-1", 0, 0, 100, 100))
+	monitor := layoutSet("monitor", 100, 0, rekt.NewRectangle("monitor-1", 0, 0, 100, 100))
+	if err := desk.AddSet(monitor); err != nil {
+		panic(err)
+	}
+
+	laptop := layoutSet("laptop", 200, 0, rekt.NewRectangle("laptop-1", 0, 0, 100, 100))
+
+	layout, err := rekt.NewLayout(desk, laptop)
+	if err != nil {
+		panic(err)
+	}
+
+	return layout, monitor, laptop
+}
+
+func TestLayoutNestedContent(t *testing.T) {
+	layout, monitor, laptop := nestedLayout()
+
+	// the laptop would overlap the monitor but not the direct children of the desk
+	require.ErrorIs(t, layout.MoveSet(laptop, 150, 0), rekt.ErrSetOverlaps)
+	require.Equal(t, 200, laptop.X)
+
+	overlapping := layoutSet("overlapping", 150, 50, rekt.NewRectangle("overlapping-1", 0, 0, 10, 10))
+	require.ErrorIs(t, layout.AddSet(overlapping), rekt.ErrSetOverlaps)
+
+	set, child := layout.ChildAt(rekt.NewPoint(150, 50))
+	require.Same(t, monitor, set)
+	require.Equal(t, world("monitor-1", 100, 0, 200, 100), *child)
+
+	query := world("query", 90, 40, 110, 60)
+	require.Equal(t, []string{"desk-1", "monitor-1"}, worldIDs(layout.Overlapping(query)))
+	require.Equal(t, []string{"monitor-1", "laptop-1"}, worldIDs(layout.Touching(world("query", 150, 100, 250, 110))))
+
+	crossing := layout.ResolveCrossing(rekt.NewPoint(95, 50), 10, 0, rekt.MapAbsolute)
+	require.NotNil(t, crossing)
+	require.Same(t, monitor, crossing.Set)
+	require.Equal(t, rekt.NewPoint(105, 50), crossing.Point)
+
+	crossing = layout.ResolveCrossing(rekt.NewPoint(195, 50), 10, 0, rekt.MapAbsolute)
+	require.NotNil(t, crossing)
+	require.Same(t, laptop, crossing.Set)
+
+	// without the monitor the desk and laptop would not be connected
+	require.Nil(t, layout.ValidateConnectivity())
+
+	graph := layout.Graph()
+	require.Len(t, graph.Nodes, 3)
+	require.Same(t, monitor, graph.Node("monitor-1").Set)
+	require.Equal(t, []string{"monitor-1"}, linkIDs(t, graph, graph.Node("desk-1").Links[rekt.Right]))
+
+	graph = rekt.NewGraph(monitor.Parent())
+	require.Len(t, graph.Nodes, 2)
+	require.Equal(t, rekt.NewRectangle("monitor-1", 100, 0, 200, 100), graph.Node("monitor-1").Rectangle)
+}
@@ -26,3 +26,17 @@ func abs(n int) int {
 
 	return n
 }
+
+// clamp restricts n to the range lower..upper (inclusive)
+func clamp(n, lower, upper int) int {
+	return max(lower, min(n, upper))
+}
+
+// floorDiv divides a by b rounding towards negative infinity rather than zero
+func floorDiv(a, b int) int {
+	if (a < 0) != (b < 0) && a%b != 0 {
+		return a/b - 1
+	}
+
+	return a / b
+}
@@ -70,3 +70,48 @@ func TestAbs(t *testing.T) {
 		})
 	}
 }
+
+var clampTests = []struct {
+	n        int
+	lower    int
+	upper    int
+	expected int
+}{
+	{5, 0, 10, 5},
+	{-1, 0, 10, 0},
+	{11, 0, 10, 10},
+	{0, 0, 10, 0},
+	{10, 0, 10, 10},
+	{-5, -10, -1, -5},
+}
+
+func TestClamp(t *testing.T) {
+	for _, testCase := range clampTests {
+		t.Run(fmt.Sprintf("n(%d) lower(%d) upper(%d)", testCase.n, testCase.lower, testCase.upper), func(t *testing.T) {
+			require.Equal(t, testCase.expected, clamp(testCase.n, testCase.lower, testCase.upper))
+		})
+	}
+}
+
+var floorDivTests = []struct {
+	a        int
+	b        int
+	expected int
+}{
+	{10, 5, 2},
+	{11, 5, 2},
+	{0, 5, 0},
+	{-1, 5, -1},
+	{-5, 5, -1},
+	{-6, 5, -2},
+	{6, -5, -2},
+	{-6, -5, 1},
+}
+
+func TestFloorDiv(t *testing.T) {
+	for _, testCase := range floorDivTests {
+		t.Run(fmt.Sprintf("a(%d) b(%d)", testCase.a, testCase.b), func(t *testing.T) {
+			require.Equal(t, testCase.expected, floorDiv(testCase.a, testCase.b))
+		})
+	}
+}
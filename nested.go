@@ -0,0 +1,330 @@
+package rekt
+
+import (
+	"errors"
+)
+
+var (
+	ErrSetNotInSet        = errors.New("set is not nested within the set")
+	ErrSetCycle           = errors.New("a set cannot be nested within itself")
+	ErrSetHasParent       = errors.New("set is already nested within another set")
+	ErrSetOverlapsContent = errors.New("nested set overlaps other content of the set")
+)
+
+// Leaf is a Rectangle found at the bottom of a tree of nested Sets
+// the coordinates of the Rectangle are relative to the space the root Set is in
+type Leaf[T comparable] struct {
+	Rectangle[T]
+	// Path holds the IDs of every Set from the root down to the Rectangle, ending with the ID
+	// of the Rectangle itself
+	Path []T
+}
+
+// ownedRectangle is a child Rectangle along with the set that it belongs to
+type ownedRectangle[T comparable] struct {
+	set  *Set[T]
+	rect Rectangle[T]
+}
+
+// AddSet nests a set within this one
+// the coords of the nested set are relative to this Set space in the same way that the coords of
+// child Rectangle's are, its id must not be in use by any child Rectangle or other nested set
+// and none of its content may overlap the children of this set or the content of the other
+// nested sets
+func (set *Set[T]) AddSet(nested *Set[T]) error {
+	if err := negativePositionError(nested.ID, nested.X, nested.Y); err != nil {
+		return err
+	}
+
+	if nested.parent != nil {
+		return ErrSetHasParent
+	}
+
+	if nested == set || nested.contains(set) {
+		return ErrSetCycle
+	}
+
+	if set.idInUse(nested.ID) {
+		return duplicateIDError(nested.ID)
+	}
+
+	if set.nestedOverlaps(nested) {
+		return ErrSetOverlapsContent
+	}
+
+	nested.parent = set
+	set.sets = append(set.sets, nested)
+	resizeSetToContent(set)
+
+	return nil
+}
+
+// RemoveSet removes the nested set with the given id and recalculates the sets dimensions
+func (set *Set[T]) RemoveSet(id T) error {
+	i := set.indexOfSet(id)
+	if i == -1 {
+		return ErrSetNotInSet
+	}
+
+	set.sets[i].parent = nil
+	set.sets = append(set.sets[:i], set.sets[i+1:]...)
+	resizeSetToContent(set)
+
+	return nil
+}
+
+// MoveSet repositions the nested set with the given id so its top left is at x,y within the set
+// if the nested set would overlap the children of the set or the content of another nested set in
+// its new position it will not be moved
+func (set *Set[T]) MoveSet(id T, x, y int) error {
+	nested := set.NestedSet(id)
+	if nested == nil {
+		return ErrSetNotInSet
+	}
+
+	if err := negativePositionError(id, x, y); err != nil {
+		return err
+	}
+
+	prevX, prevY := nested.X, nested.Y
+	nested.Move(x, y)
+
+	if set.nestedOverlaps(nested) {
+		nested.Move(prevX, prevY)
+		return ErrSetOverlapsContent
+	}
+
+	return nil
+}
+
+// NestedSet returns the directly nested set with the given id
+// nil will be returned if there is no nested set with that id
+func (set *Set[T]) NestedSet(id T) *Set[T] {
+	i := set.indexOfSet(id)
+	if i == -1 {
+		return nil
+	}
+
+	return set.sets[i]
+}
+
+// Sets returns a copy of the slice of sets directly nested within this one
+func (set *Set[T]) Sets() []*Set[T] {
+	return append([]*Set[T](nil), set.sets...)
+}
+
+// Parent returns the set that this set is nested within
+// nil will be returned if it is not nested
+func (set *Set[T]) Parent() *Set[T] {
+	return set.parent
+}
+
+// Flatten walks the full tree of nested sets and returns every Rectangle within it
+// Coordinates of the leaves will be relative to the space the set is in (world space for a root
+// set) in the same way as OffsetChildren
+func (set *Set[T]) Flatten() []Leaf[T] {
+	return flatten(set, nil, Point{})
+}
+
+// LeafAt returns the Rectangle within the tree of nested sets that contains the given point
+// the point is expected to be relative to the space the set is in (world space for a root set)
+// children of a set are checked before its nested sets
+// nil will be returned if nothing contains the point
+func (set *Set[T]) LeafAt(point Point) *Leaf[T] {
+	return leafAt(set, nil, point, Point{})
+}
+
+// flatten collects the leaves of the set with all coords moved by offset
+func flatten[T comparable](set *Set[T], path []T, offset Point) []Leaf[T] {
+	path = appendPath(path, set.ID)
+	offset = offset.Offset(set.X, set.Y)
+
+	leaves := make([]Leaf[T], 0, len(set.children))
+	for _, rect := range set.children {
+		leaves = append(leaves, newLeaf(rect, path, offset))
+	}
+
+	for _, nested := range set.sets {
+		leaves = append(leaves, flatten(nested, path, offset)...)
+	}
+
+	return leaves
+}
+
+// leafAt finds the leaf containing point, offset is the position of the space the set is in
+// relative to the root
+func leafAt[T comparable](set *Set[T], path []T, point, offset Point) *Leaf[T] {
+	path = appendPath(path, set.ID)
+	offset = offset.Offset(set.X, set.Y)
+	local := point.Offset(-set.X, -set.Y)
+
+	if child := set.ChildAt(local); child != nil {
+		leaf := newLeaf(*child, path, offset)
+		return &leaf
+	}
+
+	for _, nested := range set.sets {
+		if leaf := leafAt(nested, path, local, offset); leaf != nil {
+			return leaf
+		}
+	}
+
+	return nil
+}
+
+// newLeaf builds a leaf from a child rectangle of the set at the end of path
+func newLeaf[T comparable](rect Rectangle[T], path []T, offset Point) Leaf[T] {
+	return Leaf[T]{
+		Rectangle: rect.Offset(NewRectangle(rect.ID, offset.X, offset.Y, 0, 0)),
+		Path:      appendPath(path, rect.ID),
+	}
+}
+
+// appendPath returns a copy of path with id added to the end
+// a copy is always made so that sibling paths never share a backing array
+func appendPath[T any](path []T, id T) []T {
+	return append(append(make([]T, 0, len(path)+1), path...), id)
+}
+
+// idInUse checks if the id belongs to either a child Rectangle or a nested set
+func (set *Set[T]) idInUse(id T) bool {
+	return set.indexOf(id) != -1 || set.indexOfSet(id) != -1
+}
+
+// indexOfSet finds the position of the nested set with the given id
+// -1 will be returned if it is not found
+func (set *Set[T]) indexOfSet(id T) int {
+	for i, nested := range set.sets {
+		if nested.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// nestedOverlaps checks if any of the content of nested overlaps the children of the set or the
+// content of any other set nested within it
+func (set *Set[T]) nestedOverlaps(nested *Set[T]) bool {
+	for _, owned := range nested.content() {
+		if len(set.overlapping(owned.rect)) > 0 {
+			return true
+		}
+
+		for _, sibling := range set.sets {
+			if sibling != nested && len(sibling.contentOverlapping(owned.rect)) > 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// content returns every child of the set and of the sets nested within it along with the set
+// each one belongs to
+// Coordinates will be relative to the space the set is in (world space for a root set)
+func (set *Set[T]) content() []ownedRectangle[T] {
+	content := set.localContent()
+	for i := range content {
+		content[i].rect = content[i].rect.Offset(set.Rectangle)
+	}
+
+	return content
+}
+
+// localContent works the same as content but with coordinates relative to the Set space
+func (set *Set[T]) localContent() []ownedRectangle[T] {
+	content := make([]ownedRectangle[T], 0, len(set.children))
+	for _, rect := range set.children {
+		content = append(content, ownedRectangle[T]{set: set, rect: rect})
+	}
+
+	for _, nested := range set.sets {
+		content = append(content, nested.content()...)
+	}
+
+	return content
+}
+
+// worldContent returns the content of all of the given sets, see content
+func worldContent[T comparable](sets []*Set[T]) []ownedRectangle[T] {
+	var content []ownedRectangle[T]
+	for _, set := range sets {
+		content = append(content, set.content()...)
+	}
+
+	return content
+}
+
+// contentOverlapping returns the content of the set that overlaps the area
+// the area and the returned Rectangle's are relative to the space the set is in
+func (set *Set[T]) contentOverlapping(area Rectangle[T]) []ownedRectangle[T] {
+	local := set.localise(area)
+
+	var found []ownedRectangle[T]
+	for _, i := range set.overlapping(local) {
+		found = append(found, ownedRectangle[T]{set: set, rect: set.children[i].Offset(set.Rectangle)})
+	}
+
+	for _, nested := range set.sets {
+		for _, owned := range nested.contentOverlapping(local) {
+			owned.rect = owned.rect.Offset(set.Rectangle)
+			found = append(found, owned)
+		}
+	}
+
+	return found
+}
+
+// contentTouchingWithin returns the content of the set with an edge up to tolerance units from
+// an edge of the target
+// the target and the returned Rectangle's are relative to the space the set is in
+func (set *Set[T]) contentTouchingWithin(target Rectangle[T], tolerance int) []ownedRectangle[T] {
+	local := set.localise(target)
+
+	var found []ownedRectangle[T]
+	for _, i := range set.touchingWithin(local, tolerance) {
+		found = append(found, ownedRectangle[T]{set: set, rect: set.children[i].Offset(set.Rectangle)})
+	}
+
+	for _, nested := range set.sets {
+		for _, owned := range nested.contentTouchingWithin(local, tolerance) {
+			owned.rect = owned.rect.Offset(set.Rectangle)
+			found = append(found, owned)
+		}
+	}
+
+	return found
+}
+
+// contentAt finds the content of the set that contains the point
+// the point and the returned Rectangle are relative to the space the set is in
+// children of a set are checked before its nested sets
+func (set *Set[T]) contentAt(point Point) (ownedRectangle[T], bool) {
+	local := point.Offset(-set.X, -set.Y)
+
+	if child := set.ChildAt(local); child != nil {
+		return ownedRectangle[T]{set: set, rect: child.Offset(set.Rectangle)}, true
+	}
+
+	for _, nested := range set.sets {
+		if owned, ok := nested.contentAt(local); ok {
+			owned.rect = owned.rect.Offset(set.Rectangle)
+			return owned, true
+		}
+	}
+
+	return ownedRectangle[T]{}, false
+}
+
+// contains checks if target is nested anywhere within the set
+func (set *Set[T]) contains(target *Set[T]) bool {
+	for _, nested := range set.sets {
+		if nested == target || nested.contains(target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,187 @@
+package rekt_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+// nestedWall builds a root set holding a single rectangle and two nested sets, one of which has
+// its own nested set
+func nestedWall() *rekt.Set[string] {
+	root := layoutSet("root", 1000, 1000, rekt.NewRectangle("main", 0, 0, 100, 100))
+
+	left := layoutSet("left", 100, 0, rekt.NewRectangle("left-1", 0, 0, 50, 50))
+	inner := layoutSet("inner", 50, 0, rekt.NewRectangle("inner-1", 0, 0, 50, 50))
+	right := layoutSet("right", 0, 100, rekt.NewRectangle("right-1", 10, 10, 60, 60))
+
+	if err := left.AddSet(inner); err != nil {
+		panic(err)
+	}
+	if err := root.AddSet(left); err != nil {
+		panic(err)
+	}
+	if err := root.AddSet(right); err != nil {
+		panic(err)
+	}
+
+	return root
+}
+
+func TestSetAddSet(t *testing.T) {
+	root := layoutSet("root", 0, 0, rekt.NewRectangle("root-1", 0, 0, 100, 100))
+	nested := layoutSet("nested", 100, 0, rekt.NewRectangle("nested-1", 0, 0, 100, 100))
+
+	require.Nil(t, root.AddSet(nested))
+	require.Equal(t, []*rekt.Set[string]{nested}, root.Sets())
+	require.Equal(t, root, nested.Parent())
+	require.Equal(t, nested, root.NestedSet("nested"))
+	require.Equal(t, 200, root.W)
+
+	require.ErrorIs(t, root.AddSet(nested), rekt.ErrSetHasParent)
+	require.ErrorIs(t, root.AddSet(root), rekt.ErrSetCycle)
+	require.ErrorIs(t, root.AddSet(layoutSet("root-1", 0, 0)), rekt.ErrDuplicateID)
+	require.ErrorIs(t, root.AddSet(layoutSet("negative", -1, 0)), rekt.ErrNegativePositionInSet)
+	require.ErrorIs(t, root.AddRectangle(rekt.NewRectangle("nested", 0, 0, 10, 10)), rekt.ErrDuplicateID)
+
+	// a root can't be nested inside one of its own descendants
+	detached := layoutSet("detached", 0, 0)
+	require.Nil(t, detached.AddSet(layoutSet("child", 0, 0)))
+	require.ErrorIs(t, detached.NestedSet("child").AddSet(detached), rekt.ErrSetCycle)
+}
+
+func TestSetRemoveSet(t *testing.T) {
+	root := nestedWall()
+	left := root.NestedSet("left")
+
+	require.Nil(t, root.RemoveSet("left"))
+	require.Nil(t, left.Parent())
+	require.Nil(t, root.NestedSet("left"))
+	require.Equal(t, 1100, root.W)
+
+	require.ErrorIs(t, root.RemoveSet("left"), rekt.ErrSetNotInSet)
+}
+
+func TestSetMoveSet(t *testing.T) {
+	root := nestedWall()
+
+	require.Nil(t, root.MoveSet("left", 200, 0))
+	require.Equal(t, 200, root.NestedSet("left").X)
+	require.Equal(t, 1300, root.W)
+
+	require.ErrorIs(t, root.MoveSet("left", -1, 0), rekt.ErrNegativePositionInSet)
+	require.ErrorIs(t, root.MoveSet("missing", 0, 0), rekt.ErrSetNotInSet)
+}
+
+func TestSetNestedResize(t *testing.T) {
+	root := nestedWall()
+	inner := root.NestedSet("left").NestedSet("inner")
+
+	// changes deep in the tree are reflected all the way up
+	require.Nil(t, inner.AddRectangle(rekt.NewRectangle("inner-2", 0, 0, 500, 10)))
+	require.Equal(t, 650, root.NestedSet("left").W)
+	require.Equal(t, 1650, root.W)
+}
+
+func TestSetFlatten(t *testing.T) {
+	leaves := nestedWall().Flatten()
+
+	require.Equal(t, []rekt.Leaf[string]{
+		{Rectangle: rekt.NewRectangle("main", 1000, 1000, 1100, 1100), Path: []string{"root", "main"}},
+		{Rectangle: rekt.NewRectangle("left-1", 1100, 1000, 1150, 1050), Path: []string{"root", "left", "left-1"}},
+		{Rectangle: rekt.NewRectangle("inner-1", 1150, 1000, 1200, 1050), Path: []string{"root", "left", "inner", "inner-1"}},
+		{Rectangle: rekt.NewRectangle("right-1", 1010, 1110, 1060, 1160), Path: []string{"root", "right", "right-1"}},
+	}, leaves)
+
+	// a set without nesting flattens to its offset children
+	set := layoutSet("set", 10, 10, rekt.NewRectangle("set-1", 0, 0, 10, 10))
+	require.Equal(t, set.OffsetChildren()[0], set.Flatten()[0].Rectangle)
+}
+
+var setLeafAtTests = []struct {
+	name  string
+	point rekt.Point
+	id    string
+	path  []string
+}{
+	{"root child", rekt.NewPoint(1000, 1000), "main", []string{"root", "main"}},
+	{"nested child", rekt.NewPoint(1149, 1049), "left-1", []string{"root", "left", "left-1"}},
+	{"deeply nested child", rekt.NewPoint(1150, 1000), "inner-1", []string{"root", "left", "inner", "inner-1"}},
+	{"offset nested child", rekt.NewPoint(1010, 1110), "right-1", []string{"root", "right", "right-1"}},
+	{"gap in nested set", rekt.NewPoint(1005, 1105), "", nil},
+	{"outside", rekt.NewPoint(0, 0), "", nil},
+}
+
+func TestSetLeafAt(t *testing.T) {
+	root := nestedWall()
+
+	for _, testCase := range setLeafAtTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			leaf := root.LeafAt(testCase.point)
+
+			if testCase.path == nil {
+				require.Nil(t, leaf)
+				return
+			}
+
+			require.NotNil(t, leaf)
+			require.Equal(t, testCase.id, leaf.ID)
+			require.Equal(t, testCase.path, leaf.Path)
+			require.True(t, leaf.Contains(testCase.point))
+		})
+	}
+}
+
+func TestSetNestedJSON(t *testing.T) {
+	root := nestedWall()
+
+	data, err := json.Marshal(root)
+	require.Nil(t, err)
+
+	var decoded rekt.Set[string]
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, root.Flatten(), decoded.Flatten())
+	require.Equal(t, root.Rectangle, decoded.Rectangle)
+	require.Same(t, &decoded, decoded.NestedSet("left").Parent())
+
+	// nested ids are still checked on decode
+	var duplicate rekt.Set[string]
+	err = json.Unmarshal([]byte(`{"id":"root","x":0,"y":0,"children":[{"id":"a","x":0,"y":0,"w":1,"z":1}],"sets":[{"id":"a","x":0,"y":0,"children":[]}]}`), &duplicate)
+	require.ErrorIs(t, err, rekt.ErrDuplicateID)
+}
+
+func TestSetAddSetOverlap(t *testing.T) {
+	root := nestedWall()
+
+	// overlaps a child of the root
+	require.ErrorIs(t, root.AddSet(layoutSet("child", 50, 50, rekt.NewRectangle("child-1", 0, 0, 10, 10))), rekt.ErrSetOverlapsContent)
+
+	// overlaps the content of a set nested within a sibling
+	require.ErrorIs(t, root.AddSet(layoutSet("sibling", 160, 10, rekt.NewRectangle("sibling-1", 0, 0, 10, 10))), rekt.ErrSetOverlapsContent)
+
+	// the content of nested sets within the new set is checked too
+	deep := layoutSet("deep", 200, 0)
+	require.Nil(t, deep.AddSet(layoutSet("deep-inner", 0, 0, rekt.NewRectangle("deep-1", 0, 0, 10, 10))))
+	require.Nil(t, root.AddSet(deep))
+
+	require.Nil(t, root.RemoveSet("deep"))
+	deep.Move(0, 0)
+	require.ErrorIs(t, root.AddSet(deep), rekt.ErrSetOverlapsContent)
+	require.Len(t, root.Sets(), 2)
+}
+
+func TestSetMoveSetOverlap(t *testing.T) {
+	root := nestedWall()
+
+	require.ErrorIs(t, root.MoveSet("right", 0, 0), rekt.ErrSetOverlapsContent)
+	require.Equal(t, 0, root.NestedSet("right").X)
+	require.Equal(t, 100, root.NestedSet("right").Y)
+
+	// inner-1 sits at 150,0 -> 200,50 within the root
+	require.ErrorIs(t, root.MoveSet("right", 140, 0), rekt.ErrSetOverlapsContent)
+	require.Equal(t, 100, root.NestedSet("right").Y)
+
+	require.Nil(t, root.MoveSet("right", 200, 0))
+}
@@ -0,0 +1,202 @@
+package rekt
+
+import (
+	"fmt"
+)
+
+// Rotation defines the clockwise rotation of a display from its native orientation
+type Rotation uint8
+
+const (
+	Rotate0 Rotation = iota
+	Rotate90
+	Rotate180
+	Rotate270
+)
+
+// String implements fmt.Stringer
+func (r Rotation) String() string {
+	if r > Rotate270 {
+		return "Unknown"
+	}
+
+	return fmt.Sprintf("%d°", r.Degrees())
+}
+
+var _ fmt.Stringer = (*Rotation)(nil)
+
+// Degrees returns the clockwise rotation in degrees
+func (r Rotation) Degrees() int {
+	return int(r) * 90
+}
+
+// RotationFromDegrees converts a clockwise rotation in degrees into a Rotation
+// false will be returned if the degrees are not a multiple of 90
+func RotationFromDegrees(degrees int) (Rotation, bool) {
+	if degrees%90 != 0 {
+		return Rotate0, false
+	}
+
+	return Rotation(((degrees / 90 % 4) + 4) % 4), true
+}
+
+// swapsAxes checks if the rotation turns the width of the display into its height
+func (r Rotation) swapsAxes() bool {
+	return r == Rotate90 || r == Rotate270
+}
+
+// Orientation describes how a display has been rotated and/or flipped
+// flips are applied to the native display before it is rotated
+type Orientation struct {
+	Rotation Rotation
+	// FlipX mirrors the display horizontally
+	FlipX bool
+	// FlipY mirrors the display vertically
+	FlipY bool
+}
+
+// WithOrientation returns a copy of the rectangle with the given orientation
+// if the change in rotation swaps the axes of the display then the width and height of the
+// rectangle (and its physical size) are swapped too, the top left stays where it is
+func (rect Rectangle[T]) WithOrientation(orientation Orientation) Rectangle[T] {
+	if rect.Orientation.Rotation.swapsAxes() != orientation.Rotation.swapsAxes() {
+		width, height := rect.Width(), rect.Height()
+		rect.W = rect.X + height
+		rect.Z = rect.Y + width
+		rect.WidthMM, rect.HeightMM = rect.HeightMM, rect.WidthMM
+	}
+
+	rect.Orientation = orientation
+
+	return rect
+}
+
+// NativeWidth returns the width of the display before it was rotated
+func (rect Rectangle[T]) NativeWidth() int {
+	if rect.Orientation.Rotation.swapsAxes() {
+		return rect.Height()
+	}
+
+	return rect.Width()
+}
+
+// NativeHeight returns the height of the display before it was rotated
+func (rect Rectangle[T]) NativeHeight() int {
+	if rect.Orientation.Rotation.swapsAxes() {
+		return rect.Width()
+	}
+
+	return rect.Height()
+}
+
+// FromNative converts a point in the native coordinates of the display (top left of the
+// unrotated display being 0,0) into the same space as the rectangle
+func (rect Rectangle[T]) FromNative(native Point) Point {
+	var (
+		nw, nh = rect.NativeWidth(), rect.NativeHeight()
+		nx, ny = native.X, native.Y
+		x, y   int
+	)
+
+	if rect.Orientation.FlipX {
+		nx = nw - 1 - nx
+	}
+
+	if rect.Orientation.FlipY {
+		ny = nh - 1 - ny
+	}
+
+	switch rect.Orientation.Rotation {
+	case Rotate90:
+		x, y = nh-1-ny, nx
+	case Rotate180:
+		x, y = nw-1-nx, nh-1-ny
+	case Rotate270:
+		x, y = ny, nw-1-nx
+	default:
+		x, y = nx, ny
+	}
+
+	return NewPoint(rect.X+x, rect.Y+y)
+}
+
+// ToNative converts a point in the same space as the rectangle into the native coordinates of
+// the display
+// this is the inverse of FromNative
+func (rect Rectangle[T]) ToNative(point Point) Point {
+	var (
+		nw, nh = rect.NativeWidth(), rect.NativeHeight()
+		x, y   = point.X - rect.X, point.Y - rect.Y
+		nx, ny int
+	)
+
+	switch rect.Orientation.Rotation {
+	case Rotate90:
+		nx, ny = y, nh-1-x
+	case Rotate180:
+		nx, ny = nw-1-x, nh-1-y
+	case Rotate270:
+		nx, ny = nw-1-y, x
+	default:
+		nx, ny = x, y
+	}
+
+	if rect.Orientation.FlipX {
+		nx = nw - 1 - nx
+	}
+
+	if rect.Orientation.FlipY {
+		ny = nh - 1 - ny
+	}
+
+	return NewPoint(nx, ny)
+}
+
+// RotateRectangle changes the rotation of the child with the given id, any flips are kept
+// the top left of the child stays in place and the sets dimensions are recalculated
+func (set *Set[T]) RotateRectangle(id T, rotation Rotation) error {
+	child := set.Child(id)
+	if child == nil {
+		return ErrRectangleNotInSet
+	}
+
+	orientation := child.Orientation
+	orientation.Rotation = rotation
+
+	return set.UpdateRectangle(id, child.WithOrientation(orientation))
+}
+
+// OrientRectangle changes the full orientation (rotation and flips) of the child with the given id
+// the top left of the child stays in place and the sets dimensions are recalculated
+func (set *Set[T]) OrientRectangle(id T, orientation Orientation) error {
+	child := set.Child(id)
+	if child == nil {
+		return ErrRectangleNotInSet
+	}
+
+	return set.UpdateRectangle(id, child.WithOrientation(orientation))
+}
+
+// NativeToWorld converts a point in the native coordinates of the child with the given id into
+// world space
+// false will be returned if there is no child with that id
+func (set *Set[T]) NativeToWorld(id T, native Point) (Point, bool) {
+	child := set.Child(id)
+	if child == nil {
+		return Point{}, false
+	}
+
+	return child.Offset(set.Rectangle).FromNative(native), true
+}
+
+// WorldToNative converts a world space point into the native coordinates of the child that
+// contains it
+// false will be returned if no child contains the point
+func (set *Set[T]) WorldToNative(point Point) (id T, native Point, ok bool) {
+	child := set.OffsetChildAt(point)
+	if child == nil {
+		return id, Point{}, false
+	}
+
+	return child.ID, child.ToNative(point), true
+}
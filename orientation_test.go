@@ -0,0 +1,173 @@
+package rekt_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+var rotationFromDegreesTests = []struct {
+	degrees  int
+	expected rekt.Rotation
+	ok       bool
+}{
+	{0, rekt.Rotate0, true},
+	{90, rekt.Rotate90, true},
+	{180, rekt.Rotate180, true},
+	{270, rekt.Rotate270, true},
+	{360, rekt.Rotate0, true},
+	{-90, rekt.Rotate270, true},
+	{45, rekt.Rotate0, false},
+}
+
+func TestRotationFromDegrees(t *testing.T) {
+	for _, testCase := range rotationFromDegreesTests {
+		t.Run(fmt.Sprint(testCase.degrees), func(t *testing.T) {
+			rotation, ok := rekt.RotationFromDegrees(testCase.degrees)
+
+			require.Equal(t, testCase.ok, ok)
+			require.Equal(t, testCase.expected, rotation)
+		})
+	}
+}
+
+func TestRotationString(t *testing.T) {
+	require.Equal(t, "0°", rekt.Rotate0.String())
+	require.Equal(t, "90°", rekt.Rotate90.String())
+	require.Equal(t, "180°", rekt.Rotate180.String())
+	require.Equal(t, "270°", rekt.Rotate270.String())
+	require.Equal(t, "Unknown", rekt.Rotation(100).String())
+}
+
+func TestRectangleWithOrientation(t *testing.T) {
+	rect := rekt.NewRectangle("rect", 10, 10, 1930, 1090).WithPhysicalSize(600, 340)
+
+	portrait := rect.WithOrientation(rekt.Orientation{Rotation: rekt.Rotate90})
+	require.Equal(t, 10, portrait.X)
+	require.Equal(t, 10, portrait.Y)
+	require.Equal(t, 1080, portrait.Width())
+	require.Equal(t, 1920, portrait.Height())
+	require.Equal(t, 340, portrait.WidthMM)
+	require.Equal(t, 600, portrait.HeightMM)
+	require.Equal(t, 1920, portrait.NativeWidth())
+	require.Equal(t, 1080, portrait.NativeHeight())
+
+	// 90 -> 270 keeps the axes swapped so the size should not change
+	flipped := portrait.WithOrientation(rekt.Orientation{Rotation: rekt.Rotate270, FlipX: true})
+	require.Equal(t, portrait.W, flipped.W)
+	require.Equal(t, portrait.Z, flipped.Z)
+	require.Equal(t, rekt.Orientation{Rotation: rekt.Rotate270, FlipX: true}, flipped.Orientation)
+
+	landscape := flipped.WithOrientation(rekt.Orientation{})
+	require.Equal(t, rect, landscape)
+}
+
+var rectangleFromNativeTests = []struct {
+	orientation rekt.Orientation
+	native      rekt.Point
+	expected    rekt.Point
+}{
+	{rekt.Orientation{}, rekt.NewPoint(0, 0), rekt.NewPoint(0, 0)},
+	{rekt.Orientation{}, rekt.NewPoint(3, 1), rekt.NewPoint(3, 1)},
+	{rekt.Orientation{Rotation: rekt.Rotate90}, rekt.NewPoint(0, 0), rekt.NewPoint(1, 0)},
+	{rekt.Orientation{Rotation: rekt.Rotate90}, rekt.NewPoint(3, 1), rekt.NewPoint(0, 3)},
+	{rekt.Orientation{Rotation: rekt.Rotate180}, rekt.NewPoint(0, 0), rekt.NewPoint(3, 1)},
+	{rekt.Orientation{Rotation: rekt.Rotate270}, rekt.NewPoint(0, 0), rekt.NewPoint(0, 3)},
+	{rekt.Orientation{FlipX: true}, rekt.NewPoint(0, 0), rekt.NewPoint(3, 0)},
+	{rekt.Orientation{FlipY: true}, rekt.NewPoint(0, 0), rekt.NewPoint(0, 1)},
+	{rekt.Orientation{Rotation: rekt.Rotate90, FlipX: true}, rekt.NewPoint(0, 0), rekt.NewPoint(1, 3)},
+}
+
+func TestRectangleFromNative(t *testing.T) {
+	// native display is 4x2 positioned at 100,100
+	native := rekt.NewRectangle("native", 100, 100, 104, 102)
+
+	for _, testCase := range rectangleFromNativeTests {
+		t.Run(fmt.Sprintf("%+v %v", testCase.orientation, testCase.native), func(t *testing.T) {
+			rect := native.WithOrientation(testCase.orientation)
+			expected := testCase.expected.Offset(100, 100)
+
+			require.Equal(t, expected, rect.FromNative(testCase.native))
+			require.Equal(t, testCase.native, rect.ToNative(expected))
+		})
+	}
+}
+
+func TestRectangleNativeRoundTrip(t *testing.T) {
+	native := rekt.NewRectangle("native", 10, 20, 15, 23)
+
+	for _, rotation := range []rekt.Rotation{rekt.Rotate0, rekt.Rotate90, rekt.Rotate180, rekt.Rotate270} {
+		for _, flipX := range []bool{false, true} {
+			for _, flipY := range []bool{false, true} {
+				orientation := rekt.Orientation{Rotation: rotation, FlipX: flipX, FlipY: flipY}
+				rect := native.WithOrientation(orientation)
+				seen := map[rekt.Point]bool{}
+
+				for y := 0; y < rect.NativeHeight(); y++ {
+					for x := 0; x < rect.NativeWidth(); x++ {
+						point := rect.FromNative(rekt.NewPoint(x, y))
+
+						require.True(t, rect.Contains(point), "%+v %d,%d", orientation, x, y)
+						require.False(t, seen[point], "%+v %d,%d", orientation, x, y)
+						require.Equal(t, rekt.NewPoint(x, y), rect.ToNative(point))
+						seen[point] = true
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSetRotateRectangle(t *testing.T) {
+	var set, _ = rekt.NewSet("set", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("landscape", 0, 0, 1920, 1080),
+		rekt.NewRectangle("flipped", 1920, 0, 3840, 1080).WithOrientation(rekt.Orientation{FlipX: true}),
+	})
+	var area = set.Area()
+
+	require.ErrorIs(t, set.RotateRectangle("missing", rekt.Rotate90), rekt.ErrRectangleNotInSet)
+	require.ErrorIs(t, set.RotateRectangle("landscape", rekt.Rotation(7)), rekt.ErrBadRotation)
+
+	require.Nil(t, set.RotateRectangle("landscape", rekt.Rotate90))
+	require.Equal(t, rekt.Rotate90, set.Child("landscape").Orientation.Rotation)
+	require.Equal(t, 1080, set.Child("landscape").Width())
+	require.Equal(t, 1920, set.Child("landscape").Height())
+	require.Greater(t, set.Area(), area)
+
+	require.Nil(t, set.RotateRectangle("flipped", rekt.Rotate180))
+	require.Equal(t, rekt.Orientation{Rotation: rekt.Rotate180, FlipX: true}, set.Child("flipped").Orientation)
+
+	require.ErrorIs(t, set.OrientRectangle("missing", rekt.Orientation{}), rekt.ErrRectangleNotInSet)
+	require.Nil(t, set.OrientRectangle("flipped", rekt.Orientation{Rotation: rekt.Rotate270}))
+	require.Equal(t, rekt.Orientation{Rotation: rekt.Rotate270}, set.Child("flipped").Orientation)
+	require.Equal(t, 1080, set.Child("flipped").Width())
+}
+
+func TestSetNativeWorld(t *testing.T) {
+	var set, _ = rekt.NewSet("set", 1000, 500, []rekt.Rectangle[string]{
+		rekt.NewRectangle("landscape", 0, 0, 1920, 1080),
+		rekt.NewRectangle("portrait", 1920, 0, 3840, 1080).WithOrientation(rekt.Orientation{Rotation: rekt.Rotate90}),
+	})
+
+	point, ok := set.NativeToWorld("portrait", rekt.NewPoint(0, 0))
+	require.True(t, ok)
+	require.Equal(t, rekt.NewPoint(1000+1920+1079, 500), point)
+
+	id, native, ok := set.WorldToNative(point)
+	require.True(t, ok)
+	require.Equal(t, "portrait", id)
+	require.Equal(t, rekt.NewPoint(0, 0), native)
+
+	id, native, ok = set.WorldToNative(rekt.NewPoint(1010, 520))
+	require.True(t, ok)
+	require.Equal(t, "landscape", id)
+	require.Equal(t, rekt.NewPoint(10, 20), native)
+
+	_, ok = set.NativeToWorld("missing", rekt.NewPoint(0, 0))
+	require.False(t, ok)
+
+	_, _, ok = set.WorldToNative(rekt.NewPoint(0, 0))
+	require.False(t, ok)
+}
@@ -0,0 +1,237 @@
+package rekt
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+var (
+	ErrPackTooWide = errors.New("rectangle is wider than the packing width")
+)
+
+// PackOptions controls how Pack arranges rectangles
+type PackOptions struct {
+	// Width fixes the width of the area the rectangles are packed into
+	// the zero value lets Pack pick a width based on AspectRatio
+	Width int
+	// AspectRatio is the width / height ratio the packed area should aim for when Width is not set
+	// the zero value is treated as 1 (square)
+	AspectRatio float64
+	// Align positions the packed rectangles horizontally within the width of the area when they
+	// do not fill it, this is either Width or the width picked by Pack when Width is not set
+	Align Alignment
+}
+
+// packWidthSteps are the multiples of the ideal width that are tried when picking a width for
+// the packed area
+var packWidthSteps = []float64{0.8, 0.9, 1, 1.1, 1.2, 1.35, 1.5}
+
+// Pack arranges the rectangles into a compact area with no overlaps using a bottom left skyline
+// packer
+//
+// only the size of each rectangle is used, the returned rectangles are in the same order as
+// given with their top left moved into place (0,0 being the top left of the area), every other
+// field is kept as is
+//
+// the rectangles are validated before they are packed and ErrPackTooWide will be returned if one
+// of them does not fit within a fixed width
+func Pack[T any](rects []Rectangle[T], options PackOptions) ([]Rectangle[T], error) {
+	if len(rects) == 0 {
+		return nil, nil
+	}
+
+	var maxWidth, area int
+	for _, rect := range rects {
+		if err := rect.Validate(); err != nil {
+			return nil, err
+		}
+
+		maxWidth = max(maxWidth, rect.Width())
+		area += rect.Area()
+	}
+
+	if options.Width > 0 {
+		if maxWidth > options.Width {
+			return nil, ErrPackTooWide
+		}
+
+		packed := skylinePack(rects, options.Width)
+		alignPacked(packed, options.Width, options.Align)
+
+		return packed, nil
+	}
+
+	ratio := options.AspectRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+
+	var (
+		best      []Rectangle[T]
+		bestScore float64
+		bestWidth int
+		ideal     = math.Sqrt(float64(area) * ratio)
+	)
+
+	for _, step := range packWidthSteps {
+		width := max(maxWidth, int(math.Ceil(ideal*step)))
+		packed := skylinePack(rects, width)
+
+		bounds := outerBounds(packed)
+		score := float64(bounds.Area()) * (1 + math.Abs(math.Log(float64(bounds.Width())/float64(bounds.Height())/ratio)))
+
+		if best == nil || score < bestScore {
+			best, bestScore, bestWidth = packed, score, width
+		}
+	}
+
+	alignPacked(best, bestWidth, options.Align)
+
+	return best, nil
+}
+
+// PackSet packs the rectangles (see Pack) and adds them to a new set at x,y
+func PackSet[T comparable](id T, x, y int, rects []Rectangle[T], options PackOptions) (*Set[T], error) {
+	packed, err := Pack(rects, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSet(id, x, y, packed)
+}
+
+// skylineSegment is a horizontal section of the top of the packed area
+// the skyline runs from top to bottom so y is the lowest point that is already filled
+type skylineSegment struct {
+	x     int
+	y     int
+	width int
+}
+
+// skylinePack places the rectangles into an area of the given width
+// rectangles are placed tallest first at the position that keeps their bottom edge as high as
+// possible, ties go to the left most position
+func skylinePack[T any](rects []Rectangle[T], width int) []Rectangle[T] {
+	order := make([]int, len(rects))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := rects[order[i]], rects[order[j]]
+		if a.Height() != b.Height() {
+			return a.Height() > b.Height()
+		}
+
+		return a.Width() > b.Width()
+	})
+
+	var (
+		packed  = make([]Rectangle[T], len(rects))
+		skyline = []skylineSegment{{x: 0, y: 0, width: width}}
+	)
+
+	for _, i := range order {
+		rect := rects[i]
+		w, h := rect.Width(), rect.Height()
+
+		bestX, bestY, bestBottom := 0, 0, -1
+		for j := range skyline {
+			y, ok := skylineFit(skyline, j, w, width)
+			if !ok {
+				continue
+			}
+
+			if bestBottom == -1 || y+h < bestBottom {
+				bestX, bestY, bestBottom = skyline[j].x, y, y+h
+			}
+		}
+
+		rect.X, rect.Y = bestX, bestY
+		rect.W, rect.Z = bestX+w, bestY+h
+		packed[i] = rect
+
+		skyline = addSkylineSegment(skyline, skylineSegment{x: bestX, y: bestY + h, width: w})
+	}
+
+	return packed
+}
+
+// skylineFit finds the y a rectangle of the given width would sit at if its left edge was placed
+// at the start of segment i
+// false will be returned if the rectangle would run past the edge of the area
+func skylineFit(skyline []skylineSegment, i, width, areaWidth int) (int, bool) {
+	x := skyline[i].x
+	if x+width > areaWidth {
+		return 0, false
+	}
+
+	var y int
+	for remaining := width; remaining > 0 && i < len(skyline); i++ {
+		y = max(y, skyline[i].y)
+		remaining -= skyline[i].width
+	}
+
+	return y, true
+}
+
+// addSkylineSegment raises the skyline to the new segment, trimming or removing the segments it
+// covers and merging neighbours at the same height
+// the new segment always starts at the start of an existing segment
+func addSkylineSegment(skyline []skylineSegment, segment skylineSegment) []skylineSegment {
+	var (
+		updated  []skylineSegment
+		inserted bool
+		end      = segment.x + segment.width
+	)
+
+	for _, existing := range skyline {
+		existingEnd := existing.x + existing.width
+
+		if existingEnd <= segment.x || existing.x >= end {
+			updated = append(updated, existing)
+			continue
+		}
+
+		if !inserted {
+			updated = append(updated, segment)
+			inserted = true
+		}
+
+		// keep the part of the existing segment that sticks out past the new one
+		if existingEnd > end {
+			updated = append(updated, skylineSegment{x: end, y: existing.y, width: existingEnd - end})
+		}
+	}
+
+	var merged []skylineSegment
+	for _, s := range updated {
+		if n := len(merged); n > 0 && merged[n-1].y == s.y {
+			merged[n-1].width += s.width
+			continue
+		}
+
+		merged = append(merged, s)
+	}
+
+	return merged
+}
+
+// alignPacked moves the packed rectangles horizontally within the width of the area
+func alignPacked[T any](packed []Rectangle[T], width int, align Alignment) {
+	used := outerBounds(packed).W
+
+	var offset int
+	switch align {
+	case AlignCentre:
+		offset = (width - used) / 2
+	case AlignEnd:
+		offset = width - used
+	}
+
+	for i := range packed {
+		packed[i].X += offset
+		packed[i].W += offset
+	}
+}
@@ -0,0 +1,169 @@
+package rekt_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+func packSizes(count, w, h int) []rekt.Rectangle[string] {
+	var rects []rekt.Rectangle[string]
+	for i := 0; i < count; i++ {
+		rects = append(rects, rekt.NewRectangle(fmt.Sprint("rect-", i), 0, 0, w, h))
+	}
+
+	return rects
+}
+
+// packedBounds finds the bounding box of the packed rectangles by putting them in a set at 0,0
+func packedBounds(t *testing.T, rects []rekt.Rectangle[string]) rekt.Rectangle[string] {
+	set, err := rekt.NewSet("packed", 0, 0, rects)
+	require.Nil(t, err)
+
+	bounds, ok := set.LocalBounds()
+	require.True(t, ok)
+
+	return bounds
+}
+
+func requireNoOverlaps(t *testing.T, rects []rekt.Rectangle[string]) {
+	for i := range rects {
+		for j := i + 1; j < len(rects); j++ {
+			require.False(t, rects[i].Overlaps(rects[j]), "%s overlaps %s", rects[i].ID, rects[j].ID)
+		}
+	}
+}
+
+func TestPackSquare(t *testing.T) {
+	packed, err := rekt.Pack(packSizes(4, 100, 100), rekt.PackOptions{})
+	require.Nil(t, err)
+
+	requireNoOverlaps(t, packed)
+	require.Equal(t, rekt.NewRectangle("packed", 0, 0, 200, 200), packedBounds(t, packed))
+}
+
+func TestPackAspectRatio(t *testing.T) {
+	packed, err := rekt.Pack(packSizes(9, 1920, 1080), rekt.PackOptions{AspectRatio: 16.0 / 9})
+	require.Nil(t, err)
+
+	requireNoOverlaps(t, packed)
+	require.Equal(t, rekt.NewRectangle("packed", 0, 0, 5760, 3240), packedBounds(t, packed))
+
+	// a tall ratio stacks the displays instead
+	packed, err = rekt.Pack(packSizes(3, 1920, 1080), rekt.PackOptions{AspectRatio: 0.5})
+	require.Nil(t, err)
+	require.Equal(t, rekt.NewRectangle("packed", 0, 0, 1920, 3240), packedBounds(t, packed))
+}
+
+var packAlignTests = []struct {
+	name     string
+	align    rekt.Alignment
+	expected rekt.Rectangle[string]
+}{
+	{"start", rekt.AlignStart, rekt.NewRectangle("packed", 0, 0, 200, 100)},
+	{"centre", rekt.AlignCentre, rekt.NewRectangle("packed", 150, 0, 350, 100)},
+	{"end", rekt.AlignEnd, rekt.NewRectangle("packed", 300, 0, 500, 100)},
+}
+
+func TestPackFixedWidth(t *testing.T) {
+	packed, err := rekt.Pack(packSizes(4, 100, 100), rekt.PackOptions{Width: 300})
+	require.Nil(t, err)
+	require.Equal(t, rekt.NewRectangle("packed", 0, 0, 300, 200), packedBounds(t, packed))
+
+	for _, testCase := range packAlignTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			packed, err := rekt.Pack(packSizes(2, 100, 100), rekt.PackOptions{Width: 500, Align: testCase.align})
+			require.Nil(t, err)
+			require.Equal(t, testCase.expected, packedBounds(t, packed))
+		})
+	}
+
+	_, err = rekt.Pack(packSizes(1, 100, 100), rekt.PackOptions{Width: 99})
+	require.ErrorIs(t, err, rekt.ErrPackTooWide)
+}
+
+var packAutoWidthAlignTests = []struct {
+	name     string
+	align    rekt.Alignment
+	expected rekt.Rectangle[string]
+}{
+	{"start", rekt.AlignStart, rekt.NewRectangle("packed", 0, 0, 200, 200)},
+	{"centre", rekt.AlignCentre, rekt.NewRectangle("packed", 4, 0, 204, 200)},
+	{"end", rekt.AlignEnd, rekt.NewRectangle("packed", 8, 0, 208, 200)},
+}
+
+func TestPackAutoWidthAlign(t *testing.T) {
+	// three squares are packed into a picked width of 208 which they do not fill
+	for _, testCase := range packAutoWidthAlignTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			packed, err := rekt.Pack(packSizes(3, 100, 100), rekt.PackOptions{Align: testCase.align})
+			require.Nil(t, err)
+			require.Equal(t, testCase.expected, packedBounds(t, packed))
+		})
+	}
+}
+
+func TestPackKeepsOrderAndFields(t *testing.T) {
+	rects := []rekt.Rectangle[string]{
+		rekt.NewRectangle("small", 5, 5, 55, 55).WithScale(2),
+		rekt.NewRectangle("large", 0, 0, 200, 200).WithPhysicalSize(400, 400),
+	}
+
+	packed, err := rekt.Pack(rects, rekt.PackOptions{})
+	require.Nil(t, err)
+
+	require.Equal(t, "small", packed[0].ID)
+	require.Equal(t, 2.0, packed[0].Scale)
+	require.Equal(t, 50, packed[0].Width())
+	require.Equal(t, "large", packed[1].ID)
+	require.Equal(t, 400, packed[1].WidthMM)
+	require.Equal(t, rekt.NewRectangle("large", 0, 0, 200, 200).WithPhysicalSize(400, 400), packed[1])
+}
+
+func TestPackInvalid(t *testing.T) {
+	_, err := rekt.Pack([]rekt.Rectangle[string]{rekt.NewRectangle("zero", 0, 0, 0, 10)}, rekt.PackOptions{})
+	require.ErrorIs(t, err, rekt.ErrZoroArea)
+
+	packed, err := rekt.Pack[string](nil, rekt.PackOptions{})
+	require.Nil(t, err)
+	require.Nil(t, packed)
+}
+
+func TestPackRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	for run := 0; run < 50; run++ {
+		var rects []rekt.Rectangle[string]
+		for i := 0; i < 1+random.Intn(30); i++ {
+			rects = append(rects, rekt.NewRectangle(fmt.Sprint("rect-", i), 0, 0, 1+random.Intn(400), 1+random.Intn(400)))
+		}
+
+		options := rekt.PackOptions{AspectRatio: 0.5 + random.Float64()*2}
+		if run%2 == 0 {
+			options = rekt.PackOptions{Width: 400 + random.Intn(400), Align: rekt.Alignment(run % 3)}
+		}
+
+		packed, err := rekt.Pack(rects, options)
+		require.Nil(t, err)
+		require.Len(t, packed, len(rects))
+		requireNoOverlaps(t, packed)
+
+		for i, rect := range packed {
+			require.Nil(t, rect.Validate())
+			require.Equal(t, rects[i].Width(), rect.Width())
+			require.Equal(t, rects[i].Height(), rect.Height())
+
+			if options.Width > 0 {
+				require.GreaterOrEqual(t, rect.X, 0)
+				require.LessOrEqual(t, rect.W, options.Width)
+			}
+		}
+
+		set, err := rekt.PackSet("wall", 0, 0, rects, options)
+		require.Nil(t, err)
+		require.Len(t, set.Children(), len(rects))
+	}
+}
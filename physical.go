@@ -0,0 +1,154 @@
+package rekt
+
+import (
+	"math"
+)
+
+// WithScale returns a copy of the rectangle with its scale factor set
+func (rect Rectangle[T]) WithScale(scale float64) Rectangle[T] {
+	rect.Scale = scale
+
+	return rect
+}
+
+// WithPhysicalSize returns a copy of the rectangle with its physical dimensions (in millimetres) set
+func (rect Rectangle[T]) WithPhysicalSize(widthMM, heightMM int) Rectangle[T] {
+	rect.WidthMM = widthMM
+	rect.HeightMM = heightMM
+
+	return rect
+}
+
+// ScaleFactor returns the scale factor of the rectangle
+// unlike the Scale field this will never be 0
+func (rect Rectangle[T]) ScaleFactor() float64 {
+	if rect.Scale == 0 {
+		return 1
+	}
+
+	return rect.Scale
+}
+
+// HasPhysicalSize checks if the physical dimensions of the rectangle are known
+func (rect Rectangle[T]) HasPhysicalSize() bool {
+	return rect.WidthMM > 0 && rect.HeightMM > 0
+}
+
+// PixelWidth returns the width of the rectangle in physical pixels
+func (rect Rectangle[T]) PixelWidth() int {
+	return int(math.Round(float64(rect.Width()) * rect.ScaleFactor()))
+}
+
+// PixelHeight returns the height of the rectangle in physical pixels
+func (rect Rectangle[T]) PixelHeight() int {
+	return int(math.Round(float64(rect.Height()) * rect.ScaleFactor()))
+}
+
+// ToPhysical converts a logical point into a physical pixel on the display
+// the logical point is in the same space as the rectangle, the returned point is relative to the
+// top left of the display
+func (rect Rectangle[T]) ToPhysical(point Point) Point {
+	scale := rect.ScaleFactor()
+
+	return NewPoint(
+		int(math.Round(float64(point.X-rect.X)*scale)),
+		int(math.Round(float64(point.Y-rect.Y)*scale)),
+	)
+}
+
+// FromPhysical converts a physical pixel on the display back into a logical point
+// this is the inverse of ToPhysical
+func (rect Rectangle[T]) FromPhysical(pixel Point) Point {
+	scale := rect.ScaleFactor()
+
+	return NewPoint(
+		rect.X+int(math.Round(float64(pixel.X)/scale)),
+		rect.Y+int(math.Round(float64(pixel.Y)/scale)),
+	)
+}
+
+// ToMillimetres converts a logical point into its distance in millimetres from the top left of
+// the display
+// false will be returned if the physical size of the rectangle is not known
+func (rect Rectangle[T]) ToMillimetres(point Point) (x, y float64, ok bool) {
+	if !rect.HasPhysicalSize() {
+		return 0, 0, false
+	}
+
+	x = float64(point.X-rect.X) * rect.mmPerUnitX()
+	y = float64(point.Y-rect.Y) * rect.mmPerUnitY()
+
+	return x, y, true
+}
+
+// FromMillimetres converts a distance in millimetres from the top left of the display back into
+// a logical point
+// false will be returned if the physical size of the rectangle is not known
+func (rect Rectangle[T]) FromMillimetres(x, y float64) (Point, bool) {
+	if !rect.HasPhysicalSize() {
+		return Point{}, false
+	}
+
+	return NewPoint(
+		rect.X+int(math.Round(x/rect.mmPerUnitX())),
+		rect.Y+int(math.Round(y/rect.mmPerUnitY())),
+	), true
+}
+
+// mmPerUnitX returns the physical width of a single logical unit
+func (rect Rectangle[T]) mmPerUnitX() float64 {
+	return float64(rect.WidthMM) / float64(rect.Width())
+}
+
+// mmPerUnitY returns the physical height of a single logical unit
+func (rect Rectangle[T]) mmPerUnitY() float64 {
+	return float64(rect.HeightMM) / float64(rect.Height())
+}
+
+// ToPhysical converts a point in Set space into a physical pixel on the child that contains it
+// false will be returned if no child contains the point
+func (set *Set[T]) ToPhysical(point Point) (id T, pixel Point, ok bool) {
+	child := set.ChildAt(point)
+	if child == nil {
+		return id, Point{}, false
+	}
+
+	return child.ID, child.ToPhysical(point), true
+}
+
+// FromPhysical converts a physical pixel on the child with the given id into a point in Set space
+// false will be returned if there is no child with that id
+func (set *Set[T]) FromPhysical(id T, pixel Point) (Point, bool) {
+	child := set.Child(id)
+	if child == nil {
+		return Point{}, false
+	}
+
+	return child.FromPhysical(pixel), true
+}
+
+// ToMillimetres converts a point in Set space into its distance in millimetres from the top left
+// of the child that contains it
+// false will be returned if no child contains the point or its physical size is not known
+func (set *Set[T]) ToMillimetres(point Point) (id T, x, y float64, ok bool) {
+	child := set.ChildAt(point)
+	if child == nil {
+		return id, 0, 0, false
+	}
+
+	x, y, ok = child.ToMillimetres(point)
+
+	return child.ID, x, y, ok
+}
+
+// FromMillimetres converts a distance in millimetres from the top left of the child with the
+// given id into a point in Set space
+// false will be returned if there is no child with that id or its physical size is not known
+func (set *Set[T]) FromMillimetres(id T, x, y float64) (Point, bool) {
+	child := set.Child(id)
+	if child == nil {
+		return Point{}, false
+	}
+
+	return child.FromMillimetres(x, y)
+}
@@ -0,0 +1,137 @@
+package rekt_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+var rectangleScaleTests = []struct {
+	rect           rekt.Rectangle[string]
+	expectedScale  float64
+	expectedWidth  int
+	expectedHeight int
+}{
+	{rekt.NewRectangle("unset", 0, 0, 1920, 1080), 1, 1920, 1080},
+	{rekt.NewRectangle("100%", 0, 0, 1920, 1080).WithScale(1), 1, 1920, 1080},
+	{rekt.NewRectangle("200%", 0, 0, 1920, 1080).WithScale(2), 2, 3840, 2160},
+	{rekt.NewRectangle("150%", 0, 0, 1707, 960).WithScale(1.5), 1.5, 2561, 1440},
+}
+
+func TestRectangleScale(t *testing.T) {
+	for _, testCase := range rectangleScaleTests {
+		t.Run(testCase.rect.ID, func(t *testing.T) {
+			require.Equal(t, testCase.expectedScale, testCase.rect.ScaleFactor())
+			require.Equal(t, testCase.expectedWidth, testCase.rect.PixelWidth())
+			require.Equal(t, testCase.expectedHeight, testCase.rect.PixelHeight())
+		})
+	}
+}
+
+var rectanglePhysicalTests = []struct {
+	rect     rekt.Rectangle[string]
+	point    rekt.Point
+	expected rekt.Point
+}{
+	{rekt.NewRectangle("unscaled", 100, 100, 200, 200), rekt.NewPoint(150, 110), rekt.NewPoint(50, 10)},
+	{rekt.NewRectangle("200%", 100, 100, 200, 200).WithScale(2), rekt.NewPoint(150, 110), rekt.NewPoint(100, 20)},
+	{rekt.NewRectangle("top left", 100, 100, 200, 200).WithScale(2), rekt.NewPoint(100, 100), rekt.NewPoint(0, 0)},
+	{rekt.NewRectangle("125%", 0, 0, 100, 100).WithScale(1.25), rekt.NewPoint(40, 80), rekt.NewPoint(50, 100)},
+}
+
+func TestRectanglePhysical(t *testing.T) {
+	for _, testCase := range rectanglePhysicalTests {
+		t.Run(testCase.rect.ID, func(t *testing.T) {
+			require.Equal(t, testCase.expected, testCase.rect.ToPhysical(testCase.point))
+			require.Equal(t, testCase.point, testCase.rect.FromPhysical(testCase.expected))
+		})
+	}
+}
+
+func TestRectangleMillimetres(t *testing.T) {
+	unknown := rekt.NewRectangle("unknown", 0, 0, 1920, 1080)
+	require.False(t, unknown.HasPhysicalSize())
+
+	_, _, ok := unknown.ToMillimetres(rekt.NewPoint(10, 10))
+	require.False(t, ok)
+
+	_, ok = unknown.FromMillimetres(10, 10)
+	require.False(t, ok)
+
+	known := rekt.NewRectangle("known", 100, 100, 1060, 640).WithPhysicalSize(480, 270)
+	require.True(t, known.HasPhysicalSize())
+
+	x, y, ok := known.ToMillimetres(rekt.NewPoint(580, 370))
+	require.True(t, ok)
+	require.InDelta(t, 240, x, 0.001)
+	require.InDelta(t, 135, y, 0.001)
+
+	point, ok := known.FromMillimetres(240, 135)
+	require.True(t, ok)
+	require.Equal(t, rekt.NewPoint(580, 370), point)
+}
+
+func physicalSet() *rekt.Set[string] {
+	return layoutSet("desk", 1000, 1000,
+		rekt.NewRectangle("4k", 0, 0, 1920, 1080).WithScale(2).WithPhysicalSize(600, 340),
+		rekt.NewRectangle("1080p", 1920, 0, 3840, 1080),
+	)
+}
+
+var setPhysicalTests = []struct {
+	point    rekt.Point
+	id       string
+	expected rekt.Point
+}{
+	{rekt.NewPoint(10, 20), "4k", rekt.NewPoint(20, 40)},
+	{rekt.NewPoint(1930, 20), "1080p", rekt.NewPoint(10, 20)},
+	{rekt.NewPoint(5000, 20), "", rekt.Point{}},
+}
+
+func TestSetPhysical(t *testing.T) {
+	set := physicalSet()
+
+	for _, testCase := range setPhysicalTests {
+		t.Run(fmt.Sprintf("%v", testCase.point), func(t *testing.T) {
+			id, pixel, ok := set.ToPhysical(testCase.point)
+			if testCase.id == "" {
+				require.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			require.Equal(t, testCase.id, id)
+			require.Equal(t, testCase.expected, pixel)
+
+			point, ok := set.FromPhysical(id, pixel)
+			require.True(t, ok)
+			require.Equal(t, testCase.point, point)
+		})
+	}
+
+	_, ok := set.FromPhysical("missing", rekt.NewPoint(0, 0))
+	require.False(t, ok)
+}
+
+func TestSetMillimetres(t *testing.T) {
+	set := physicalSet()
+
+	id, x, y, ok := set.ToMillimetres(rekt.NewPoint(960, 540))
+	require.True(t, ok)
+	require.Equal(t, "4k", id)
+	require.InDelta(t, 300, x, 0.001)
+	require.InDelta(t, 170, y, 0.001)
+
+	point, ok := set.FromMillimetres("4k", 300, 170)
+	require.True(t, ok)
+	require.Equal(t, rekt.NewPoint(960, 540), point)
+
+	id, _, _, ok = set.ToMillimetres(rekt.NewPoint(2000, 10))
+	require.False(t, ok)
+	require.Equal(t, "1080p", id)
+
+	_, ok = set.FromMillimetres("missing", 0, 0)
+	require.False(t, ok)
+}
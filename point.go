@@ -0,0 +1,23 @@
+package rekt
+
+// Point represents a single location in 2d space
+// whether the point is relative to a Set or to world space depends on where it came from, much
+// like the coords of a Rectangle
+type Point struct {
+	X int
+	Y int
+}
+
+// NewPoint simply fills out the fields of a Point struct
+// It is just a convinience method to avoid explicit constructon
+func NewPoint(x, y int) Point {
+	return Point{X: x, Y: y}
+}
+
+// Offset returns a copy of the Point with its coords offset by the given x,y
+func (p Point) Offset(x, y int) Point {
+	p.X += x
+	p.Y += y
+
+	return p
+}
@@ -0,0 +1,28 @@
+package rekt_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+var pointOffsetTests = []struct {
+	point    rekt.Point
+	x        int
+	y        int
+	expected rekt.Point
+}{
+	{rekt.NewPoint(0, 0), 10, 10, rekt.NewPoint(10, 10)},
+	{rekt.NewPoint(5, 5), -10, -10, rekt.NewPoint(-5, -5)},
+	{rekt.NewPoint(5, 5), 0, 0, rekt.NewPoint(5, 5)},
+}
+
+func TestPointOffset(t *testing.T) {
+	for _, testCase := range pointOffsetTests {
+		t.Run(fmt.Sprintf("%v + %d,%d", testCase.point, testCase.x, testCase.y), func(t *testing.T) {
+			require.Equal(t, testCase.expected, testCase.point.Offset(testCase.x, testCase.y))
+		})
+	}
+}
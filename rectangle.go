@@ -2,11 +2,16 @@ package rekt
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 var (
-	ErrZoroArea  = errors.New("rectangles must have an area")
-	ErrBadPoints = errors.New("rectangle has invalid coords")
+	ErrZoroArea        = errors.New("rectangles must have an area")
+	ErrBadPoints       = errors.New("rectangle has invalid coords")
+	ErrBadScale        = errors.New("rectangle scale must not be negative")
+	ErrBadPhysicalSize = errors.New("rectangle physical size must not be negative")
+	ErrBadRotation     = errors.New("rectangle has an unknown rotation")
 )
 
 // Rectongle represents points in space describing a rectangle
@@ -20,6 +25,19 @@ type Rectangle[T any] struct {
 	// bottom right
 	W int
 	Z int
+
+	// Scale is the scale factor of the display the rectangle represents
+	// e.g. 2 for a 4k display running at 200%
+	// the zero value is treated as a scale of 1
+	Scale float64
+	// WidthMM and HeightMM are the physical dimensions of the display in millimetres
+	// they follow the rectangle as it is laid out, not the native orientation of the display
+	// the zero value means that the physical size is not known
+	WidthMM  int
+	HeightMM int
+	// Orientation is the rotation/flip of the display
+	// the coords of the rectangle are always those of the display as it is laid out (after rotation)
+	Orientation Orientation
 }
 
 // NewRectangle simply fills out the fields of a Rectangle struct
@@ -72,6 +90,15 @@ func (rect Rectangle[T]) Overlaps(target Rectangle[T]) bool {
 	return true
 }
 
+// Contains checks if the given point falls within the rectangle
+// Much like Overlaps the rectangle is treated as half open, the top and left edges are inside
+// of the rectangle but the bottom and right edges are not, this means that a point on the
+// shared edge of two touching rectangles will only ever be contained by one of them
+func (rect Rectangle[T]) Contains(point Point) bool {
+	return point.X >= rect.X && point.X < rect.W &&
+		point.Y >= rect.Y && point.Y < rect.Z
+}
+
 // OverlappingArea returns (if any) the bounding box of the area where the rectangle overlaps
 // with the target rectangle
 // nil will be returned if the two rectangles do not overlap
@@ -94,6 +121,24 @@ func (rect Rectangle[T]) OverlappingArea(target Rectangle[T]) *Rectangle[T] {
 	return &overlap
 }
 
+// boundingBox calculates the smallest Rectangle that contains all of the given rectangles
+// false will be returned if there are no rectangles to bound
+func boundingBox[T any](id T, rects []Rectangle[T]) (Rectangle[T], bool) {
+	if len(rects) == 0 {
+		return Rectangle[T]{ID: id}, false
+	}
+
+	bounds := NewRectangle(id, rects[0].X, rects[0].Y, rects[0].W, rects[0].Z)
+	for _, rect := range rects[1:] {
+		bounds.X = min(bounds.X, rect.X)
+		bounds.Y = min(bounds.Y, rect.Y)
+		bounds.W = max(bounds.W, rect.W)
+		bounds.Z = max(bounds.Z, rect.Z)
+	}
+
+	return bounds, true
+}
+
 // Touches returns a slice of sides in which the reciever Rectangle touches the target
 // It does not care about overlapps so an internal rectangle that has a
 // touching edge will be found
@@ -170,6 +215,15 @@ func (rect Rectangle[T]) TouchCoordinates(target Rectangle[T], edge Edge) *EdgeC
 	return nil
 }
 
+// neighbourCoordinates works the same as TouchCoordinates but will only report a touch if the
+// target is on the outside of the given edge, rectangles that share the edge from the inside
+// (such as a child sharing an edge with its surrounding rectangle) are ignored
+func (rect Rectangle[T]) neighbourCoordinates(target Rectangle[T], edge Edge) *EdgeCoordinates[T] {
+	coords, _ := rect.neighbourCoordinatesWithin(target, edge, 0)
+
+	return coords
+}
+
 // touchesTop checks if the top of rect touches the top or bottom of target
 func touchesTop[T any](rect, target Rectangle[T]) bool {
 	return (rect.Y == target.Z || rect.Y == target.Y) &&
@@ -198,14 +252,63 @@ func touchesLeft[T any](rect, target Rectangle[T]) bool {
 // - Area of the rectangle must not be 0
 // - X,Y must be top left
 // - W,Z must be bottom right
+// - Scale, WidthMM and HeightMM must not be negative
+// - Orientation must have a known Rotation
+//
+// every problem found is reported as a *ValidationError, if there is more than one they are
+// combined with errors.Join
 func (rect Rectangle[T]) Validate() error {
-	if rect.Area() == 0 {
-		return ErrZoroArea
+	return joinErrors(rect.validationErrors())
+}
+
+// validationErrors finds every problem with the rectangle, see Validate
+func (rect Rectangle[T]) validationErrors() []error {
+	var (
+		errs    []error
+		fields  []string
+		reasons []string
+	)
+
+	if rect.Width() == 0 {
+		fields = append(fields, "X", "W")
+		reasons = append(reasons, "width is 0")
+	} else if rect.X > rect.W {
+		errs = append(errs, newValidationError(rect.ID, ErrBadPoints,
+			fmt.Sprintf("X (%d) is greater than W (%d)", rect.X, rect.W), "X", "W"))
 	}
 
-	if rect.X > rect.W || rect.Y > rect.Z {
-		return ErrBadPoints
+	if rect.Height() == 0 {
+		fields = append(fields, "Y", "Z")
+		reasons = append(reasons, "height is 0")
+	} else if rect.Y > rect.Z {
+		errs = append(errs, newValidationError(rect.ID, ErrBadPoints,
+			fmt.Sprintf("Y (%d) is greater than Z (%d)", rect.Y, rect.Z), "Y", "Z"))
 	}
 
-	return nil
+	if len(fields) > 0 {
+		zero := newValidationError(rect.ID, ErrZoroArea, strings.Join(reasons, " and "), fields...)
+		errs = append([]error{zero}, errs...)
+	}
+
+	if rect.Scale < 0 {
+		errs = append(errs, newValidationError(rect.ID, ErrBadScale,
+			fmt.Sprintf("Scale is %v", rect.Scale), "Scale"))
+	}
+
+	if rect.WidthMM < 0 {
+		errs = append(errs, newValidationError(rect.ID, ErrBadPhysicalSize,
+			fmt.Sprintf("WidthMM is %v", rect.WidthMM), "WidthMM"))
+	}
+
+	if rect.HeightMM < 0 {
+		errs = append(errs, newValidationError(rect.ID, ErrBadPhysicalSize,
+			fmt.Sprintf("HeightMM is %v", rect.HeightMM), "HeightMM"))
+	}
+
+	if rect.Orientation.Rotation > Rotate270 {
+		errs = append(errs, newValidationError(rect.ID, ErrBadRotation,
+			fmt.Sprintf("Rotation is %d", rect.Orientation.Rotation), "Orientation.Rotation"))
+	}
+
+	return errs
 }
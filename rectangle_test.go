@@ -183,6 +183,11 @@ var rectangleValidityTests = []struct {
 	{rekt.NewRectangle("flipped points", 0, 0, -10, -10), rekt.ErrBadPoints},
 	{rekt.NewRectangle("valid", 0, 0, 10, 10), nil},
 	{rekt.NewRectangle("valid in negative", -10, -10, 0, 0), nil},
+	{rekt.NewRectangle("negative scale", 0, 0, 10, 10).WithScale(-1), rekt.ErrBadScale},
+	{rekt.NewRectangle("negative physical width", 0, 0, 10, 10).WithPhysicalSize(-1, 10), rekt.ErrBadPhysicalSize},
+	{rekt.NewRectangle("negative physical height", 0, 0, 10, 10).WithPhysicalSize(10, -1), rekt.ErrBadPhysicalSize},
+	{rekt.NewRectangle("valid display", 0, 0, 10, 10).WithScale(2).WithPhysicalSize(10, 10), nil},
+	{rekt.NewRectangle("unknown rotation", 0, 0, 10, 10).WithOrientation(rekt.Orientation{Rotation: 4}), rekt.ErrBadRotation},
 }
 
 func TestRectangleValidate(t *testing.T) {
@@ -191,7 +196,7 @@ func TestRectangleValidate(t *testing.T) {
 			if testCase.expected == nil {
 				require.Nil(t, testCase.rect.Validate())
 			} else {
-				require.ErrorIs(t, testCase.expected, testCase.rect.Validate())
+				require.ErrorIs(t, testCase.rect.Validate(), testCase.expected)
 			}
 		})
 	}
@@ -205,12 +210,12 @@ var rectangleOverlappingAreaTests = []struct {
 	{
 		rekt.NewRectangle("overlap-1", 0, 0, 10, 10),
 		rekt.NewRectangle("overlap-2", 5, 5, 15, 15),
-		&rekt.Rectangle[string]{"overlap-2", 5, 5, 10, 10},
+		&rekt.Rectangle[string]{ID: "overlap-2", X: 5, Y: 5, W: 10, Z: 10},
 	},
 	{
 		rekt.NewRectangle("overlap-2", 5, 5, 15, 15),
 		rekt.NewRectangle("overlap-1", 0, 0, 10, 10),
-		&rekt.Rectangle[string]{"overlap-1", 5, 5, 10, 10},
+		&rekt.Rectangle[string]{ID: "overlap-1", X: 5, Y: 5, W: 10, Z: 10},
 	},
 	{
 		rekt.NewRectangle("no-overlap-1", 0, 0, 10, 10),
@@ -320,3 +325,41 @@ func TestRectangleEdgeCoordinates(t *testing.T) {
 		})
 	}
 }
+
+var rectangleContainsTests = []struct {
+	name     string
+	rect     rekt.Rectangle[string]
+	point    rekt.Point
+	expected bool
+}{
+	{"inside", rekt.NewRectangle("rect", 0, 0, 10, 10), rekt.NewPoint(5, 5), true},
+	{"top left corner", rekt.NewRectangle("rect", 0, 0, 10, 10), rekt.NewPoint(0, 0), true},
+	{"top edge", rekt.NewRectangle("rect", 0, 0, 10, 10), rekt.NewPoint(5, 0), true},
+	{"left edge", rekt.NewRectangle("rect", 0, 0, 10, 10), rekt.NewPoint(0, 5), true},
+	{"right edge", rekt.NewRectangle("rect", 0, 0, 10, 10), rekt.NewPoint(10, 5), false},
+	{"bottom edge", rekt.NewRectangle("rect", 0, 0, 10, 10), rekt.NewPoint(5, 10), false},
+	{"bottom right corner", rekt.NewRectangle("rect", 0, 0, 10, 10), rekt.NewPoint(10, 10), false},
+	{"last pixel", rekt.NewRectangle("rect", 0, 0, 10, 10), rekt.NewPoint(9, 9), true},
+	{"outside", rekt.NewRectangle("rect", 0, 0, 10, 10), rekt.NewPoint(20, 20), false},
+	{"negative space", rekt.NewRectangle("rect", -10, -10, 0, 0), rekt.NewPoint(-1, -1), true},
+}
+
+func TestRectangleContains(t *testing.T) {
+	for _, testCase := range rectangleContainsTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, testCase.rect.Contains(testCase.point))
+		})
+	}
+}
+
+func TestRectangleContainsTouchingRectangles(t *testing.T) {
+	left := rekt.NewRectangle("left", 0, 0, 10, 10)
+	right := rekt.NewRectangle("right", 10, 0, 20, 10)
+
+	for y := 0; y < 10; y++ {
+		point := rekt.NewPoint(10, y)
+
+		require.False(t, left.Contains(point))
+		require.True(t, right.Contains(point))
+	}
+}
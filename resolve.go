@@ -0,0 +1,107 @@
+package rekt
+
+// maxResolveIterations is the number of pushes a single set is allowed before ResolveOverlaps
+// gives up on the minimum translation and moves the set clear of everything instead
+const maxResolveIterations = 100
+
+// ResolveOverlaps pushes sets apart until neither their bounding boxes nor their children overlap
+//
+// sets are processed in the order they are given, a set is never moved to make room for one that
+// comes after it, this makes the result deterministic and means the first set never moves
+//
+// each overlapping set is pushed along the axis of least overlap (minimum translation), in the
+// unlikely event that this does not settle the set will be placed to the right of all the sets
+// before it
+//
+// the sets that were moved are returned in the order they were processed
+func ResolveOverlaps[T comparable](sets []*Set[T]) []*Set[T] {
+	var moved []*Set[T]
+
+	for i, set := range sets {
+		bounds, ok := set.WorldBounds()
+		if !ok {
+			continue
+		}
+
+		var (
+			placed     = placedBounds(sets[:i])
+			startX     = set.X
+			startY     = set.Y
+			iterations int
+		)
+
+		for {
+			dx, dy, overlaps := minimumTranslation(bounds, placed)
+			if !overlaps {
+				break
+			}
+
+			if iterations == maxResolveIterations {
+				dx, dy = outerBounds(placed).W-bounds.X, 0
+			}
+
+			set.Move(set.X+dx, set.Y+dy)
+			bounds = bounds.Offset(NewRectangle(set.ID, dx, dy, 0, 0))
+			iterations++
+		}
+
+		if set.X != startX || set.Y != startY {
+			moved = append(moved, set)
+		}
+	}
+
+	return moved
+}
+
+// placedBounds returns the world space bounding boxes of the given sets
+// sets without any children are skipped
+func placedBounds[T comparable](sets []*Set[T]) []Rectangle[T] {
+	var bounds []Rectangle[T]
+
+	for _, set := range sets {
+		if rect, ok := set.WorldBounds(); ok {
+			bounds = append(bounds, rect)
+		}
+	}
+
+	return bounds
+}
+
+// outerBounds returns the bounding box that surrounds all of the given rectangles
+func outerBounds[T any](rects []Rectangle[T]) Rectangle[T] {
+	var id T
+	bounds, _ := boundingBox(id, rects)
+
+	return bounds
+}
+
+// minimumTranslation calculates the shortest push that will move rect out of the first of the
+// placed rectangles it overlaps
+// false will be returned if rect does not overlap any of them
+//
+// the push is along the axis with the least overlap (horizontal in the case of a tie) and away
+// from the centre of the placed rectangle (right/down in the case of a tie)
+func minimumTranslation[T any](rect Rectangle[T], placed []Rectangle[T]) (dx, dy int, ok bool) {
+	for _, target := range placed {
+		overlap := rect.OverlappingArea(target)
+		if overlap == nil {
+			continue
+		}
+
+		if overlap.Width() <= overlap.Height() {
+			if rect.X+rect.W < target.X+target.W {
+				return target.X - rect.W, 0, true
+			}
+
+			return target.W - rect.X, 0, true
+		}
+
+		if rect.Y+rect.Z < target.Y+target.Z {
+			return 0, target.Y - rect.Z, true
+		}
+
+		return 0, target.Z - rect.Y, true
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,160 @@
+package rekt_test
+
+import (
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+type expectedPosition struct {
+	x int
+	y int
+}
+
+var resolveOverlapsTests = []struct {
+	name     string
+	sets     func() []*rekt.Set[string]
+	moved    []string
+	expected []expectedPosition
+}{
+	{
+		"no overlap",
+		func() []*rekt.Set[string] {
+			return []*rekt.Set[string]{
+				layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+				layoutSet("b", 100, 0, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+			}
+		},
+		nil,
+		[]expectedPosition{{0, 0}, {100, 0}},
+	},
+	{
+		"pushed right",
+		func() []*rekt.Set[string] {
+			return []*rekt.Set[string]{
+				layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+				layoutSet("b", 90, 0, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+			}
+		},
+		[]string{"b"},
+		[]expectedPosition{{0, 0}, {100, 0}},
+	},
+	{
+		"pushed left",
+		func() []*rekt.Set[string] {
+			return []*rekt.Set[string]{
+				layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+				layoutSet("b", -90, 0, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+			}
+		},
+		[]string{"b"},
+		[]expectedPosition{{0, 0}, {-100, 0}},
+	},
+	{
+		"pushed down along the shallow axis",
+		func() []*rekt.Set[string] {
+			return []*rekt.Set[string]{
+				layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+				layoutSet("b", 20, 95, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+			}
+		},
+		[]string{"b"},
+		[]expectedPosition{{0, 0}, {20, 100}},
+	},
+	{
+		"pushed up",
+		func() []*rekt.Set[string] {
+			return []*rekt.Set[string]{
+				layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+				layoutSet("b", 20, -95, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+			}
+		},
+		[]string{"b"},
+		[]expectedPosition{{0, 0}, {20, -100}},
+	},
+	{
+		"exact overlap is pushed right",
+		func() []*rekt.Set[string] {
+			return []*rekt.Set[string]{
+				layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+				layoutSet("b", 0, 0, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+			}
+		},
+		[]string{"b"},
+		[]expectedPosition{{0, 0}, {100, 0}},
+	},
+	{
+		"chain of pushes",
+		func() []*rekt.Set[string] {
+			return []*rekt.Set[string]{
+				layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+				layoutSet("b", 100, 0, rekt.NewRectangle("b-1", 0, 0, 100, 100)),
+				layoutSet("c", 90, 0, rekt.NewRectangle("c-1", 0, 0, 100, 100)),
+			}
+		},
+		[]string{"c"},
+		[]expectedPosition{{0, 0}, {100, 0}, {200, 0}},
+	},
+	{
+		"bounding boxes are separated",
+		func() []*rekt.Set[string] {
+			return []*rekt.Set[string]{
+				layoutSet("a", 0, 0,
+					rekt.NewRectangle("a-1", 0, 0, 10, 100),
+					rekt.NewRectangle("a-2", 10, 90, 100, 100),
+				),
+				layoutSet("b", 10, 0, rekt.NewRectangle("b-1", 0, 0, 90, 85)),
+			}
+		},
+		[]string{"b"},
+		[]expectedPosition{{0, 0}, {10, -85}},
+	},
+}
+
+func TestResolveOverlaps(t *testing.T) {
+	for _, testCase := range resolveOverlapsTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			sets := testCase.sets()
+			moved := rekt.ResolveOverlaps(sets)
+
+			var movedIDs []string
+			for _, set := range moved {
+				movedIDs = append(movedIDs, set.ID)
+			}
+			require.Equal(t, testCase.moved, movedIDs)
+
+			for i, set := range sets {
+				require.Equal(t, testCase.expected[i].x, set.X, set.ID)
+				require.Equal(t, testCase.expected[i].y, set.Y, set.ID)
+			}
+
+			_, err := rekt.NewLayout(sets...)
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestResolveOverlapsIsDeterministic(t *testing.T) {
+	build := func() []*rekt.Set[string] {
+		var sets []*rekt.Set[string]
+		for i := 0; i < 10; i++ {
+			sets = append(sets, layoutSet("set", i*7, i*3, rekt.NewRectangle("child", 0, 0, 50, 30)))
+		}
+
+		return sets
+	}
+
+	first, second := build(), build()
+	rekt.ResolveOverlaps(first)
+	rekt.ResolveOverlaps(second)
+
+	for i := range first {
+		require.Equal(t, first[i].X, second[i].X)
+		require.Equal(t, first[i].Y, second[i].Y)
+
+		for _, other := range first[:i] {
+			require.False(t, first[i].OffsetChildren()[0].Overlaps(other.OffsetChildren()[0]))
+		}
+	}
+}
@@ -2,75 +2,425 @@ package rekt
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 var (
 	ErrNegativePositionInSet = errors.New("rectangles must have posotive coords")
+	ErrRectangleNotInSet     = errors.New("rectangle is not part of the set")
+	ErrDuplicateID           = errors.New("rectangle id is already in use within the set")
 )
 
 // Set defines a group of rectangles
 // wasnt sure what to call this, it was eitge Set or Murder
-type Set[T any] struct {
+//
+// children are identified by their ID so it must be unique within the set
+//
+// the embedded Rectangle is in the same space the set is positioned in (world space unless the
+// set is nested) and runs from the position of the set to the furthest corner of its content,
+// the tight bounds of the content are available from LocalBounds and WorldBounds
+type Set[T comparable] struct {
 	Rectangle[T]
 	children []Rectangle[T]
+	// index maps the ID of each child to its position in children
+	index map[T]int
+	// grid is an optional spatial index over the children, see EnableIndex
+	grid *Grid[T]
+	// sets holds any nested sets, see AddSet
+	sets []*Set[T]
+	// parent is the set that this set is nested within (if any)
+	parent *Set[T]
 }
 
 // NewSet fills out the fields of the set struct with the given types
-func NewSet[T any](id T, x, y int, children []Rectangle[T]) (*Set[T], error) {
+func NewSet[T comparable](id T, x, y int, children []Rectangle[T]) (*Set[T], error) {
 	set := &Set[T]{
 		Rectangle: Rectangle[T]{
 			ID: id,
 			X:  x,
 			Y:  y,
 		},
+		index: make(map[T]int),
 	}
 
+	var errs []error
 	for _, rect := range children {
 		if err := set.AddRectangle(rect); err != nil {
-			return nil, err
+			errs = append(errs, err)
 		}
 	}
 
+	if err := joinErrors(errs); err != nil {
+		return nil, err
+	}
+
+	resizeSetToContent(set)
+
 	return set, nil
 }
 
 // AddRectangle adds a rectangle to the set and recalculates the sets dimensions
 func (set *Set[T]) AddRectangle(rect Rectangle[T]) error {
-	if err := rect.Validate(); err != nil {
+	if err := validateChild(rect); err != nil {
+		return err
+	}
+
+	if set.idInUse(rect.ID) {
+		return duplicateIDError(rect.ID)
+	}
+
+	if set.index == nil {
+		set.index = make(map[T]int)
+	}
+
+	set.children = append(set.children, rect)
+	set.index[rect.ID] = len(set.children) - 1
+	if set.grid != nil {
+		set.grid.Insert(rect)
+	}
+
+	resizeSetToContent(set)
+
+	return nil
+}
+
+// RemoveRectangle removes the child with the given id from the set and recalculates the sets
+// dimensions
+func (set *Set[T]) RemoveRectangle(id T) error {
+	i := set.indexOf(id)
+	if i == -1 {
+		return ErrRectangleNotInSet
+	}
+
+	set.children = append(set.children[:i], set.children[i+1:]...)
+	delete(set.index, id)
+	set.reindexFrom(i)
+	if set.grid != nil {
+		set.grid.Remove(i)
+	}
+
+	resizeSetToContent(set)
+
+	return nil
+}
+
+// UpdateRectangle replaces the child with the given id with rect and recalculates the sets
+// dimensions
+// the replacement goes through the same validation as AddRectangle
+func (set *Set[T]) UpdateRectangle(id T, rect Rectangle[T]) error {
+	i := set.indexOf(id)
+	if i == -1 {
+		return ErrRectangleNotInSet
+	}
+
+	if err := validateChild(rect); err != nil {
+		return err
+	}
+
+	if rect.ID != id && set.idInUse(rect.ID) {
+		return duplicateIDError(rect.ID)
+	}
+
+	set.children[i] = rect
+	if rect.ID != id {
+		delete(set.index, id)
+		set.index[rect.ID] = i
+	}
+
+	if set.grid != nil {
+		set.grid.Update(i, rect)
+	}
+
+	resizeSetToContent(set)
+
+	return nil
+}
+
+// MoveRectangle repositions the child with the given id so its top left is at x,y within the set
+// the size of the child is kept the same
+func (set *Set[T]) MoveRectangle(id T, x, y int) error {
+	i := set.indexOf(id)
+	if i == -1 {
+		return ErrRectangleNotInSet
+	}
+
+	rect := set.children[i]
+	rect.W += x - rect.X
+	rect.Z += y - rect.Y
+	rect.X = x
+	rect.Y = y
+
+	return set.UpdateRectangle(id, rect)
+}
+
+// ResizeRectangle changes the size of the child with the given id keeping its top left in place
+func (set *Set[T]) ResizeRectangle(id T, width, height int) error {
+	i := set.indexOf(id)
+	if i == -1 {
+		return ErrRectangleNotInSet
+	}
+
+	rect := set.children[i]
+	rect.W = rect.X + width
+	rect.Z = rect.Y + height
+
+	return set.UpdateRectangle(id, rect)
+}
+
+// insertRectangle adds the rectangle to the set at position i within its children
+// the rectangle goes through the same validation as AddRectangle
+func (set *Set[T]) insertRectangle(i int, rect Rectangle[T]) error {
+	if err := validateChild(rect); err != nil {
 		return err
 	}
 
-	if rect.X < 0 || rect.Y < 0 {
-		return ErrNegativePositionInSet
+	if set.idInUse(rect.ID) {
+		return duplicateIDError(rect.ID)
+	}
+
+	if set.index == nil {
+		set.index = make(map[T]int)
 	}
 
+	i = clamp(i, 0, len(set.children))
+
 	set.children = append(set.children, rect)
+	copy(set.children[i+1:], set.children[i:])
+	set.children[i] = rect
+	set.reindexFrom(i)
+	if set.grid != nil {
+		set.grid.insertAt(i, rect)
+	}
+
 	resizeSetToContent(set)
 
 	return nil
 }
 
+// validateChild checks that the rectangle is valid to be added as a child of a set
+func validateChild[T any](rect Rectangle[T]) error {
+	errs := rect.validationErrors()
+
+	if err := negativePositionError(rect.ID, rect.X, rect.Y); err != nil {
+		errs = append(errs, err)
+	}
+
+	return joinErrors(errs)
+}
+
+// negativePositionError describes a child or nested set placed at a negative position within a set
+// nil is returned if the position is not negative
+func negativePositionError[T any](id T, x, y int) error {
+	var fields, reasons []string
+
+	if x < 0 {
+		fields = append(fields, "X")
+		reasons = append(reasons, fmt.Sprintf("X is %d", x))
+	}
+
+	if y < 0 {
+		fields = append(fields, "Y")
+		reasons = append(reasons, fmt.Sprintf("Y is %d", y))
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return newValidationError(id, ErrNegativePositionInSet, strings.Join(reasons, " and "), fields...)
+}
+
+// Child returns the child Rectangle with the given id
+// Coordinates of the child will be relative to the Set space
+// nil will be returned if there is no child with that id
+func (set *Set[T]) Child(id T) *Rectangle[T] {
+	i := set.indexOf(id)
+	if i == -1 {
+		return nil
+	}
+
+	return &set.children[i]
+}
+
+// indexOf finds the position of the child with the given id in the set
+// -1 will be returned if it is not found
+func (set *Set[T]) indexOf(id T) int {
+	if i, ok := set.index[id]; ok {
+		return i
+	}
+
+	return -1
+}
+
+// reindexFrom updates the ID index for the children from position i onwards
+// used after children have been shifted along by an insert or removal
+func (set *Set[T]) reindexFrom(i int) {
+	for ; i < len(set.children); i++ {
+		set.index[set.children[i].ID] = i
+	}
+}
+
+// reindexSet rebuilds the ID index (and spatial index if enabled) of the set from its children
+// this is only needed when the children are replaced wholesale, single changes keep the indexes up
+// to date as they go
+func reindexSet[T comparable](set *Set[T]) {
+	set.index = make(map[T]int, len(set.children))
+
+	for i, rect := range set.children {
+		set.index[rect.ID] = i
+	}
+
+	if set.grid != nil {
+		set.grid.reset(set.children)
+	}
+}
+
+// EnableIndex builds a spatial index over the children of the set that will be kept up to date
+// as the set is changed
+//
+// once enabled ChildAt, OverlapsChildren and TouchesChildren (along with everything built on
+// them) will use the index rather than checking every child, this is only worth doing for sets
+// with a large number of children
+//
+// see NewGrid for how cellSize is used
+func (set *Set[T]) EnableIndex(cellSize int) {
+	set.grid = NewGrid(cellSize, set.children)
+}
+
+// DisableIndex removes the spatial index from the set
+func (set *Set[T]) DisableIndex() {
+	set.grid = nil
+}
+
+// Indexed checks if the set has a spatial index enabled
+func (set *Set[T]) Indexed() bool {
+	return set.grid != nil
+}
+
+// overlapping returns the positions of the children that overlap the Set space area
+func (set *Set[T]) overlapping(area Rectangle[T]) []int {
+	if set.grid != nil {
+		return set.grid.Query(area)
+	}
+
+	var found []int
+	for i, rect := range set.children {
+		if area.Overlaps(rect) {
+			found = append(found, i)
+		}
+	}
+
+	return found
+}
+
+// touching returns the positions of the children that touch an edge of the Set space rectangle
+func (set *Set[T]) touching(target Rectangle[T]) []int {
+	return set.touchingWithin(target, 0)
+}
+
+// touchingWithin returns the positions of the children with an edge up to tolerance units from
+// an edge of the Set space rectangle
+func (set *Set[T]) touchingWithin(target Rectangle[T], tolerance int) []int {
+	if set.grid != nil {
+		return set.grid.TouchingWithin(target, tolerance)
+	}
+
+	var found []int
+	for i, rect := range set.children {
+		if target.TouchesWithin(rect, tolerance) != nil {
+			found = append(found, i)
+		}
+	}
+
+	return found
+}
+
+// Move repositions the set within world space
+// the children of the set will move with it as their coords are relative to the set
+func (set *Set[T]) Move(x, y int) {
+	set.X = x
+	set.Y = y
+
+	resizeSetToContent(set)
+}
+
 // resizeSetToContent calculates and sets the bottom right corner and therefore size of
-// a set based on the Rectangle's in it
-func resizeSetToContent[T any](set *Set[T]) {
+// a set based on the Rectangle's (and nested Set's) in it
+//
+// the bottom right is the furthest corner of the content offset by the position of the set, this
+// keeps the whole of the embedded Rectangle in the same space as the set is positioned in
+// an empty set will have a bottom right equal to its top left
+//
+// if the set is nested within another set then the parent is resized as well
+func resizeSetToContent[T comparable](set *Set[T]) {
+	var w, z int
+
 	for _, rect := range set.children {
-		set.W = max(set.W, rect.X+rect.W)
-		set.Z = max(set.Z, rect.Y+rect.Z)
+		w = max(w, rect.W)
+		z = max(z, rect.Z)
+	}
+
+	// nested sets are already positioned in this Set space
+	for _, nested := range set.sets {
+		if nested.Area() == 0 {
+			continue
+		}
+
+		w = max(w, nested.W)
+		z = max(z, nested.Z)
+	}
+
+	set.W = set.X + w
+	set.Z = set.Y + z
+
+	if set.parent != nil {
+		resizeSetToContent(set.parent)
 	}
 }
 
+// LocalBounds calculates the tight bounding box of the content of the set (its children and
+// any non empty nested sets)
+// Coordinates of the bounding box will be relative to the Set space
+// false will be returned if the set has no content
+func (set *Set[T]) LocalBounds() (Rectangle[T], bool) {
+	content := append([]Rectangle[T](nil), set.children...)
+
+	for _, nested := range set.sets {
+		if bounds, ok := nested.WorldBounds(); ok {
+			content = append(content, bounds)
+		}
+	}
+
+	return boundingBox(set.ID, content)
+}
+
+// WorldBounds calculates the tight bounding box of the content of the set
+// Coordinates of the bounding box will be relative to the space the set is positioned in (world
+// space for a set that is not nested)
+// false will be returned if the set has no content
+func (set *Set[T]) WorldBounds() (Rectangle[T], bool) {
+	bounds, ok := set.LocalBounds()
+	if !ok {
+		return bounds, false
+	}
+
+	return bounds.Offset(set.Rectangle), true
+}
+
 // OverlapsChildren returns a slice of child Rectangle's  of the provided Set that overlap with the
 // bounding box of recievers Set
 // this doesnt check if the bounding boxes of the sets overlap, that can be done with
 //
 //  set.Overlaps(target.Rectangle)
+//
+// the children of target are compared in their local Set space so this is only correct when
+// target is at 0,0, see OverlapsWorldChildren for a world space version
 func (set *Set[T]) OverlapsChildren(target Set[T]) []Rectangle[T] {
 	var overlapping []Rectangle[T]
 
-	for _, rect := range target.children {
-		if set.Overlaps(rect) {
-			overlapping = append(overlapping, rect)
-		}
+	for _, i := range target.overlapping(set.Rectangle) {
+		overlapping = append(overlapping, target.children[i])
 	}
 
 	return overlapping
@@ -81,13 +431,14 @@ func (set *Set[T]) OverlapsChildren(target Set[T]) []Rectangle[T] {
 // this doesnt check if the bounding boxes of the sets touch, that can be done with
 //
 //  set.Tocches(target.Rectangle)
+//
+// the children of target are compared in their local Set space so this is only correct when
+// target is at 0,0, see TouchesWorldChildren for a world space version
 func (set *Set[T]) TouchesChildren(target Set[T]) []Rectangle[T] {
 	var touching []Rectangle[T]
 
-	for _, rect := range target.children {
-		if set.Touches(rect) != nil {
-			touching = append(touching, rect)
-		}
+	for _, i := range target.touching(set.Rectangle) {
+		touching = append(touching, target.children[i])
 	}
 
 	return touching
@@ -106,18 +457,54 @@ func (set *Set[T]) OffsetChildren() []Rectangle[T] {
 	var offsetChildren = make([]Rectangle[T], 0, len(set.children))
 
 	for _, rect := range set.children {
-		offsetChildren = append(offsetChildren, Rectangle[T]{
-			ID: rect.ID,
-			X:  rect.X + set.X,
-			Y:  rect.Y + set.Y,
-			W:  rect.W + set.X,
-			Z:  rect.Z + set.Y,
-		})
+		offsetChildren = append(offsetChildren, rect.Offset(set.Rectangle))
 	}
 
 	return offsetChildren
 }
 
+// localise returns a copy of the world space rectangle with its coords made relative to the
+// Set space
+func (set *Set[T]) localise(rect Rectangle[T]) Rectangle[T] {
+	return rect.Offset(NewRectangle(rect.ID, -set.X, -set.Y, 0, 0))
+}
+
+// ChildAt returns the child Rectangle that contains the given point
+// the point is expected to be relative to the Set space
+// nil will be returned if no child contains the point
+func (set *Set[T]) ChildAt(point Point) *Rectangle[T] {
+	if set.grid != nil {
+		if found := set.grid.At(point); len(found) > 0 {
+			return &set.children[found[0]]
+		}
+
+		return nil
+	}
+
+	for i := range set.children {
+		if set.children[i].Contains(point) {
+			return &set.children[i]
+		}
+	}
+
+	return nil
+}
+
+// OffsetChildAt returns a copy of the child Rectangle that contains the given point
+// the point is expected to be relative to world space and the returned Rectangle will also have
+// its coordinates offset into world space
+// nil will be returned if no child contains the point
+func (set *Set[T]) OffsetChildAt(point Point) *Rectangle[T] {
+	child := set.ChildAt(point.Offset(-set.X, -set.Y))
+	if child == nil {
+		return nil
+	}
+
+	offset := child.Offset(set.Rectangle)
+
+	return &offset
+}
+
 // ChildOnEdge finds the Rectangle closest to the priorityEdge within the Set
 // Should more than one Rectangle be equally close then the one closest to the secondaryEdge
 // will be picked
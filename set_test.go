@@ -3,6 +3,7 @@ package rekt_test
 import (
 	"fmt"
 	"testing"
+	"testing/quick"
 
 	"github.com/indeedhat/rekt"
 	"github.com/stretchr/testify/require"
@@ -19,7 +20,7 @@ var setAddRectangleTests = []struct {
 	{rekt.NewRectangle("flipped points", 0, 0, -10, -10), rekt.ErrBadPoints, 0},
 	{rekt.NewRectangle("negative position", -10, -10, 0, 0), rekt.ErrNegativePositionInSet, 0},
 	{rekt.NewRectangle("valid", 0, 0, 10, 10), nil, 100},
-	{rekt.NewRectangle("valid offset", 10, 10, 100, 100), nil, 12100},
+	{rekt.NewRectangle("valid offset", 10, 10, 100, 100), nil, 10000},
 }
 
 func TestNewSet(t *testing.T) {
@@ -27,7 +28,7 @@ func TestNewSet(t *testing.T) {
 		t.Run(testCase.rect.ID, func(t *testing.T) {
 			set, err := rekt.NewSet("test set", 0, 0, []rekt.Rectangle[string]{testCase.rect})
 			if testCase.expected != nil {
-				require.ErrorIs(t, testCase.expected, err)
+				require.ErrorIs(t, err, testCase.expected)
 				return
 			}
 
@@ -44,7 +45,7 @@ func TestSetAddRectangle(t *testing.T) {
 	for _, testCase := range setAddRectangleTests {
 		t.Run(testCase.rect.ID, func(t *testing.T) {
 			if testCase.expected != nil {
-				require.ErrorIs(t, testCase.expected, set.AddRectangle(testCase.rect))
+				require.ErrorIs(t, set.AddRectangle(testCase.rect), testCase.expected)
 				return
 			}
 
@@ -121,7 +122,7 @@ func TestSetTouchesChildren(t *testing.T) {
 func TestSetChildren(t *testing.T) {
 	var multipleChildren = []rekt.Rectangle[string]{
 		rekt.NewRectangle("rect-1", 0, 0, 10, 10),
-		rekt.NewRectangle("rect-1", 10, 10, 15, 20),
+		rekt.NewRectangle("rect-2", 10, 10, 15, 20),
 	}
 	var multiple, _ = rekt.NewSet("multiple", 10, 10, multipleChildren)
 
@@ -152,7 +153,7 @@ func TestSetChildren(t *testing.T) {
 func TestSetOffsetChildren(t *testing.T) {
 	var multipleChildren = []rekt.Rectangle[string]{
 		rekt.NewRectangle("rect-1", 0, 0, 10, 10),
-		rekt.NewRectangle("rect-1", 10, 10, 15, 20),
+		rekt.NewRectangle("rect-2", 10, 10, 15, 20),
 	}
 	var multiple, _ = rekt.NewSet("multiple", 10, 10, multipleChildren)
 
@@ -220,3 +221,356 @@ func TestSetChildOnEdge(t *testing.T) {
 	require.Equal(t, "top-left", set.ChildOnEdge(rekt.Left, rekt.Top).ID)
 	require.Equal(t, "bottom-left", set.ChildOnEdge(rekt.Left, rekt.Bottom).ID)
 }
+
+var setChildAtTests = []struct {
+	point    rekt.Point
+	expected string
+}{
+	{rekt.NewPoint(0, 0), "left"},
+	{rekt.NewPoint(9, 9), "left"},
+	{rekt.NewPoint(10, 0), "right"},
+	{rekt.NewPoint(19, 9), "right"},
+	{rekt.NewPoint(20, 0), ""},
+	{rekt.NewPoint(0, 10), ""},
+	{rekt.NewPoint(-1, 0), ""},
+}
+
+func TestSetChildAt(t *testing.T) {
+	var set, _ = rekt.NewSet("set", 100, 100, []rekt.Rectangle[string]{
+		rekt.NewRectangle("left", 0, 0, 10, 10),
+		rekt.NewRectangle("right", 10, 0, 20, 10),
+	})
+
+	for _, testCase := range setChildAtTests {
+		t.Run(fmt.Sprintf("%v", testCase.point), func(t *testing.T) {
+			child := set.ChildAt(testCase.point)
+			if testCase.expected == "" {
+				require.Nil(t, child)
+				return
+			}
+
+			require.NotNil(t, child)
+			require.Equal(t, testCase.expected, child.ID)
+		})
+	}
+}
+
+func TestSetOffsetChildAt(t *testing.T) {
+	var set, _ = rekt.NewSet("set", 100, 100, []rekt.Rectangle[string]{
+		rekt.NewRectangle("left", 0, 0, 10, 10),
+		rekt.NewRectangle("right", 10, 0, 20, 10),
+	})
+
+	for _, testCase := range setChildAtTests {
+		point := testCase.point.Offset(set.X, set.Y)
+
+		t.Run(fmt.Sprintf("%v", point), func(t *testing.T) {
+			child := set.OffsetChildAt(point)
+			if testCase.expected == "" {
+				require.Nil(t, child)
+				return
+			}
+
+			require.NotNil(t, child)
+			require.Equal(t, testCase.expected, child.ID)
+			require.True(t, child.Contains(point))
+			require.Equal(t, *set.ChildAt(testCase.point), child.Offset(rekt.NewRectangle("", -set.X, -set.Y, 0, 0)))
+		})
+	}
+}
+
+func TestSetMove(t *testing.T) {
+	var set, _ = rekt.NewSet("set", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("rect-1", 0, 0, 10, 10),
+	})
+	var children = set.Children()
+
+	set.Move(100, 50)
+
+	require.Equal(t, 100, set.X)
+	require.Equal(t, 50, set.Y)
+	require.Equal(t, children, set.Children())
+	require.Equal(t, 100, set.OffsetChildren()[0].X)
+	require.Equal(t, 50, set.OffsetChildren()[0].Y)
+}
+
+func TestSetRemoveRectangle(t *testing.T) {
+	var set, _ = rekt.NewSet("set", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("rect-1", 0, 0, 10, 10),
+		rekt.NewRectangle("rect-2", 10, 0, 20, 10),
+		rekt.NewRectangle("rect-3", 0, 10, 10, 20),
+	})
+	var area = set.Area()
+
+	require.ErrorIs(t, set.RemoveRectangle("missing"), rekt.ErrRectangleNotInSet)
+	require.Len(t, set.Children(), 3)
+
+	require.Nil(t, set.RemoveRectangle("rect-3"))
+	require.Equal(t, []rekt.Rectangle[string]{
+		rekt.NewRectangle("rect-1", 0, 0, 10, 10),
+		rekt.NewRectangle("rect-2", 10, 0, 20, 10),
+	}, set.Children())
+	require.Less(t, set.Area(), area)
+
+	require.Nil(t, set.RemoveRectangle("rect-1"))
+	require.Nil(t, set.RemoveRectangle("rect-2"))
+	require.Empty(t, set.Children())
+	require.Equal(t, 0, set.Area())
+}
+
+var setUpdateRectangleTests = []struct {
+	name     string
+	id       string
+	rect     rekt.Rectangle[string]
+	expected error
+}{
+	{"missing", "missing", rekt.NewRectangle("missing", 0, 0, 10, 10), rekt.ErrRectangleNotInSet},
+	{"zero size", "rect-2", rekt.NewRectangle("rect-2", 0, 0, 0, 0), rekt.ErrZoroArea},
+	{"flipped points", "rect-2", rekt.NewRectangle("rect-2", 0, 0, -10, -10), rekt.ErrBadPoints},
+	{"negative position", "rect-2", rekt.NewRectangle("rect-2", -10, -10, 0, 0), rekt.ErrNegativePositionInSet},
+	{"grow", "rect-2", rekt.NewRectangle("rect-2", 10, 0, 50, 40), nil},
+	{"shrink", "rect-2", rekt.NewRectangle("rect-2", 10, 0, 15, 5), nil},
+}
+
+func TestSetUpdateRectangle(t *testing.T) {
+	for _, testCase := range setUpdateRectangleTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			var set, _ = rekt.NewSet("set", 0, 0, []rekt.Rectangle[string]{
+				rekt.NewRectangle("rect-1", 0, 0, 10, 10),
+				rekt.NewRectangle("rect-2", 10, 0, 20, 10),
+			})
+			var original = append([]rekt.Rectangle[string](nil), set.Children()...)
+			var area = set.Area()
+
+			err := set.UpdateRectangle(testCase.id, testCase.rect)
+			if testCase.expected != nil {
+				require.ErrorIs(t, err, testCase.expected)
+				require.Equal(t, original, set.Children())
+				require.Equal(t, area, set.Area())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, testCase.rect, set.Children()[1])
+
+			expected, _ := rekt.NewSet("expected", 0, 0, []rekt.Rectangle[string]{original[0], testCase.rect})
+			require.Equal(t, expected.Area(), set.Area())
+		})
+	}
+}
+
+func TestSetMoveRectangle(t *testing.T) {
+	var set, _ = rekt.NewSet("set", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("rect-1", 0, 0, 10, 10),
+		rekt.NewRectangle("rect-2", 10, 0, 20, 10),
+	})
+
+	require.ErrorIs(t, set.MoveRectangle("missing", 0, 0), rekt.ErrRectangleNotInSet)
+	require.ErrorIs(t, set.MoveRectangle("rect-2", -1, 0), rekt.ErrNegativePositionInSet)
+	require.Equal(t, rekt.NewRectangle("rect-2", 10, 0, 20, 10), set.Children()[1])
+
+	require.Nil(t, set.MoveRectangle("rect-2", 0, 10))
+	require.Equal(t, rekt.NewRectangle("rect-2", 0, 10, 10, 20), set.Children()[1])
+}
+
+func TestSetResizeRectangle(t *testing.T) {
+	var set, _ = rekt.NewSet("set", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("rect-1", 0, 0, 10, 10),
+		rekt.NewRectangle("rect-2", 10, 0, 20, 10),
+	})
+
+	require.ErrorIs(t, set.ResizeRectangle("missing", 10, 10), rekt.ErrRectangleNotInSet)
+	require.ErrorIs(t, set.ResizeRectangle("rect-2", 0, 10), rekt.ErrZoroArea)
+	require.Equal(t, rekt.NewRectangle("rect-2", 10, 0, 20, 10), set.Children()[1])
+
+	require.Nil(t, set.ResizeRectangle("rect-2", 30, 20))
+	require.Equal(t, rekt.NewRectangle("rect-2", 10, 0, 40, 20), set.Children()[1])
+	require.Equal(t, 40*20, set.Area())
+}
+
+func TestSetAddRectangleDuplicateID(t *testing.T) {
+	var set, _ = rekt.NewSet("set", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("rect-1", 0, 0, 10, 10),
+	})
+
+	require.ErrorIs(t, set.AddRectangle(rekt.NewRectangle("rect-1", 10, 0, 20, 10)), rekt.ErrDuplicateID)
+	require.Len(t, set.Children(), 1)
+
+	_, err := rekt.NewSet("set", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("rect-1", 0, 0, 10, 10),
+		rekt.NewRectangle("rect-1", 10, 0, 20, 10),
+	})
+	require.ErrorIs(t, err, rekt.ErrDuplicateID)
+}
+
+func TestSetChild(t *testing.T) {
+	var set, _ = rekt.NewSet("set", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("HDMI-1", 0, 0, 10, 10),
+		rekt.NewRectangle("DP-1", 10, 0, 20, 10),
+		rekt.NewRectangle("DP-2", 20, 0, 30, 10),
+	})
+
+	require.Nil(t, set.Child("missing"))
+	require.Equal(t, rekt.NewRectangle("DP-1", 10, 0, 20, 10), *set.Child("DP-1"))
+
+	// the index has to follow the children around as they are mutated
+	require.Nil(t, set.RemoveRectangle("HDMI-1"))
+	require.Nil(t, set.Child("HDMI-1"))
+	require.Equal(t, rekt.NewRectangle("DP-1", 10, 0, 20, 10), *set.Child("DP-1"))
+	require.Equal(t, rekt.NewRectangle("DP-2", 20, 0, 30, 10), *set.Child("DP-2"))
+
+	require.Nil(t, set.UpdateRectangle("DP-2", rekt.NewRectangle("DP-3", 20, 0, 40, 10)))
+	require.Nil(t, set.Child("DP-2"))
+	require.Equal(t, rekt.NewRectangle("DP-3", 20, 0, 40, 10), *set.Child("DP-3"))
+
+	require.Nil(t, set.MoveRectangle("DP-3", 0, 10))
+	require.Equal(t, rekt.NewRectangle("DP-3", 0, 10, 20, 20), *set.Child("DP-3"))
+
+	require.Nil(t, set.AddRectangle(rekt.NewRectangle("HDMI-1", 30, 0, 40, 10)))
+	require.Equal(t, rekt.NewRectangle("HDMI-1", 30, 0, 40, 10), *set.Child("HDMI-1"))
+}
+
+func TestSetUpdateRectangleDuplicateID(t *testing.T) {
+	var set, _ = rekt.NewSet("set", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("rect-1", 0, 0, 10, 10),
+		rekt.NewRectangle("rect-2", 10, 0, 20, 10),
+	})
+
+	require.ErrorIs(t, set.UpdateRectangle("rect-2", rekt.NewRectangle("rect-1", 10, 0, 20, 10)), rekt.ErrDuplicateID)
+	require.Equal(t, rekt.NewRectangle("rect-2", 10, 0, 20, 10), *set.Child("rect-2"))
+}
+
+func TestSetZeroValue(t *testing.T) {
+	var set rekt.Set[string]
+
+	require.Nil(t, set.Child("rect-1"))
+	require.Nil(t, set.AddRectangle(rekt.NewRectangle("rect-1", 0, 0, 10, 10)))
+	require.NotNil(t, set.Child("rect-1"))
+}
+
+func TestSetOffsetChildrenKeepsDisplayInfo(t *testing.T) {
+	var set, _ = rekt.NewSet("set", 10, 10, []rekt.Rectangle[string]{
+		rekt.NewRectangle("rect-1", 0, 0, 10, 10).WithScale(2).WithPhysicalSize(100, 100),
+	})
+
+	child := set.OffsetChildren()[0]
+	require.Equal(t, 2.0, child.Scale)
+	require.Equal(t, 100, child.WidthMM)
+	require.Equal(t, 100, child.HeightMM)
+}
+
+// quickSet builds a set at x,y from randomly generated children then removes some of them
+// each child is built from 4 bytes so it always has a positive position and area
+func quickSet(x, y int16, children [][4]uint8, removals []uint8) *rekt.Set[int] {
+	set, _ := rekt.NewSet(-1, int(x), int(y), nil)
+
+	for i, c := range children {
+		x, y := int(c[0]), int(c[1])
+		_ = set.AddRectangle(rekt.NewRectangle(i, x, y, x+1+int(c[2]), y+1+int(c[3])))
+	}
+
+	for _, i := range removals {
+		if len(children) > 0 {
+			_ = set.RemoveRectangle(int(i) % len(children))
+		}
+	}
+
+	return set
+}
+
+func TestSetBoundsProperties(t *testing.T) {
+	t.Run("rectangle spans from the set position to the furthest child", func(t *testing.T) {
+		require.Nil(t, quick.Check(func(x, y int16, children [][4]uint8, removals []uint8) bool {
+			set := quickSet(x, y, children, removals)
+
+			w, z := set.X, set.Y
+			for _, child := range set.OffsetChildren() {
+				if child.W > w {
+					w = child.W
+				}
+				if child.Z > z {
+					z = child.Z
+				}
+			}
+
+			return set.W == w && set.Z == z
+		}, nil))
+	})
+
+	t.Run("world bounds tightly contain every child", func(t *testing.T) {
+		require.Nil(t, quick.Check(func(x, y int16, children [][4]uint8, removals []uint8) bool {
+			set := quickSet(x, y, children, removals)
+			offsetChildren := set.OffsetChildren()
+
+			bounds, ok := set.WorldBounds()
+			if !ok {
+				return len(offsetChildren) == 0 && set.Area() == 0
+			}
+
+			var top, right, bottom, left bool
+			for _, child := range offsetChildren {
+				if overlap := bounds.OverlappingArea(child); overlap == nil || *overlap != child {
+					return false
+				}
+
+				top = top || child.Y == bounds.Y
+				right = right || child.W == bounds.W
+				bottom = bottom || child.Z == bounds.Z
+				left = left || child.X == bounds.X
+			}
+
+			return top && right && bottom && left
+		}, nil))
+	})
+
+	t.Run("local and world bounds differ only by the set position", func(t *testing.T) {
+		require.Nil(t, quick.Check(func(x, y int16, children [][4]uint8, removals []uint8) bool {
+			set := quickSet(x, y, children, removals)
+
+			local, localOK := set.LocalBounds()
+			world, worldOK := set.WorldBounds()
+
+			return localOK == worldOK && (!localOK || local.Offset(set.Rectangle) == world)
+		}, nil))
+	})
+
+	t.Run("bounds follow the set when it moves", func(t *testing.T) {
+		require.Nil(t, quick.Check(func(x, y, dx, dy int16, children [][4]uint8) bool {
+			set := quickSet(x, y, children, nil)
+			before := set.Rectangle
+
+			set.Move(set.X+int(dx), set.Y+int(dy))
+
+			return set.Rectangle == before.Offset(rekt.NewRectangle(-1, int(dx), int(dy), 0, 0))
+		}, nil))
+	})
+}
+
+func TestSetBoundsShrinkOnRemoval(t *testing.T) {
+	set, _ := rekt.NewSet("set", 100, 100, []rekt.Rectangle[string]{
+		rekt.NewRectangle("small", 0, 0, 10, 10),
+		rekt.NewRectangle("large", 10, 10, 100, 100),
+	})
+	require.Equal(t, rekt.NewRectangle("set", 100, 100, 200, 200), set.Rectangle)
+
+	require.Nil(t, set.RemoveRectangle("large"))
+	require.Equal(t, rekt.NewRectangle("set", 100, 100, 110, 110), set.Rectangle)
+
+	require.Nil(t, set.RemoveRectangle("small"))
+	require.Equal(t, rekt.NewRectangle("set", 100, 100, 100, 100), set.Rectangle)
+
+	_, ok := set.WorldBounds()
+	require.False(t, ok)
+}
+
+func TestSetOverlapsOffsetSets(t *testing.T) {
+	// neither set is at the origin, their bounding boxes sit side by side
+	left, _ := rekt.NewSet("left", 100, 100, []rekt.Rectangle[string]{rekt.NewRectangle("left-1", 0, 0, 100, 100)})
+	right, _ := rekt.NewSet("right", 200, 100, []rekt.Rectangle[string]{rekt.NewRectangle("right-1", 0, 0, 100, 100)})
+
+	require.False(t, left.Overlaps(right.Rectangle))
+	require.Equal(t, []rekt.Edge{rekt.Right}, left.Touches(right.Rectangle))
+
+	right.Move(150, 100)
+	require.True(t, left.Overlaps(right.Rectangle))
+}
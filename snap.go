@@ -0,0 +1,129 @@
+package rekt
+
+// Snap describes the result of snapping a Set against its neighbours
+type Snap[T comparable] struct {
+	// X,Y is the position of the set after snapping
+	// if no snap took place this will be the proposed position
+	X int
+	Y int
+	// Target is the set that was snapped against, nil if no snap took place
+	Target *Set[T]
+	// Edge is the edge of the snapped set that touches the Target
+	Edge Edge
+	// TargetEdge is the edge of the Target that the snapped set touches
+	TargetEdge Edge
+}
+
+// Snapped reports if the set was snapped against another set
+func (snap Snap[T]) Snapped() bool {
+	return snap.Target != nil
+}
+
+// SnapSet works out where the set should be placed if it were moved to x,y taking into account
+// the other sets around it
+//
+// if an edge of the sets bounding box comes within threshold of the opposing edge of another
+// set it will be snapped flush against it, should multiple edges be in range the closest wins
+// snaps that would cause the set to overlap the children of another set are ignored
+//
+// the set itself is not moved
+func SnapSet[T comparable](set *Set[T], x, y int, others []*Set[T], threshold int) Snap[T] {
+	snap := Snap[T]{X: x, Y: y}
+
+	bounds, ok := set.LocalBounds()
+	if !ok {
+		return snap
+	}
+
+	moving := bounds.Offset(NewRectangle(set.ID, x, y, 0, 0))
+	closest := threshold + 1
+
+	for _, other := range others {
+		if other == set {
+			continue
+		}
+
+		target, ok := other.WorldBounds()
+		if !ok {
+			continue
+		}
+
+		for _, edge := range []Edge{Top, Right, Bottom, Left} {
+			dx, dy, ok := snapDistance(moving, target, edge)
+			distance := abs(dx) + abs(dy)
+			if !ok || distance >= closest {
+				continue
+			}
+
+			if !verifySnap(set, x+dx, y+dy, moving.Offset(NewRectangle(set.ID, dx, dy, 0, 0)), target, edge, others) {
+				continue
+			}
+
+			closest = distance
+			snap = Snap[T]{
+				X:          x + dx,
+				Y:          y + dy,
+				Target:     other,
+				Edge:       edge,
+				TargetEdge: edge.Opposite(),
+			}
+		}
+	}
+
+	return snap
+}
+
+// SnapSet works out where the set should be placed within the layout if it were moved to x,y
+// see SnapSet for more details
+func (layout *Layout[T]) SnapSet(set *Set[T], x, y, threshold int) Snap[T] {
+	return SnapSet(set, x, y, layout.sets, threshold)
+}
+
+// snapDistance calculates how far the moving rectangle needs to be shifted in order for its edge
+// to sit flush against the opposing edge of the target
+// false will be returned if the rectangles are not lined up in a way that allows them to touch
+// along that edge
+func snapDistance[T any](moving, target Rectangle[T], edge Edge) (dx, dy int, ok bool) {
+	switch edge {
+	case Top, Bottom:
+		if moving.X >= target.W || moving.W <= target.X {
+			return 0, 0, false
+		}
+	case Right, Left:
+		if moving.Y >= target.Z || moving.Z <= target.Y {
+			return 0, 0, false
+		}
+	}
+
+	switch edge {
+	case Top:
+		return 0, target.Z - moving.Y, true
+	case Right:
+		return target.X - moving.W, 0, true
+	case Bottom:
+		return 0, target.Y - moving.Z, true
+	case Left:
+		return target.W - moving.X, 0, true
+	}
+
+	return 0, 0, false
+}
+
+// verifySnap checks that the set placed at x,y has its edge flush against the target without
+// any of its children overlapping those of the other sets
+func verifySnap[T comparable](set *Set[T], x, y int, moved, target Rectangle[T], edge Edge, others []*Set[T]) bool {
+	if moved.neighbourCoordinates(target, edge) == nil {
+		return false
+	}
+
+	candidate := *set
+	candidate.Move(x, y)
+
+	for _, other := range others {
+		if other != set && setsOverlap(&candidate, other) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,92 @@
+package rekt_test
+
+import (
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+var snapSetTests = []struct {
+	name       string
+	x          int
+	y          int
+	threshold  int
+	expectedX  int
+	expectedY  int
+	target     string
+	edge       rekt.Edge
+	targetEdge rekt.Edge
+}{
+	{"out of range", 300, 300, 10, 300, 300, "", 0, 0},
+	{"left of anchor", 92, 10, 10, 100, 10, "anchor", rekt.Left, rekt.Right},
+	{"right of anchor", -55, 10, 10, -50, 10, "anchor", rekt.Right, rekt.Left},
+	{"below anchor", 10, 108, 10, 10, 100, "anchor", rekt.Top, rekt.Bottom},
+	{"above anchor", 10, -57, 10, 10, -50, "anchor", rekt.Bottom, rekt.Top},
+	{"exactly at threshold", 90, 10, 10, 100, 10, "anchor", rekt.Left, rekt.Right},
+	{"just past threshold", 89, 10, 10, 89, 10, "", 0, 0},
+	{"not lined up", 105, 105, 10, 105, 105, "", 0, 0},
+	{"closest snap wins anchor", 102, 0, 10, 100, 0, "anchor", rekt.Left, rekt.Right},
+	{"closest snap wins neighbour", 108, 0, 10, 110, 0, "neighbour", rekt.Right, rekt.Left},
+	{"already flush", 100, 10, 10, 100, 10, "anchor", rekt.Left, rekt.Right},
+}
+
+func TestSnapSet(t *testing.T) {
+	anchor := layoutSet("anchor", 0, 0, rekt.NewRectangle("anchor-1", 0, 0, 100, 100))
+	neighbour := layoutSet("neighbour", 160, 0, rekt.NewRectangle("neighbour-1", 0, 0, 50, 50))
+	moving := layoutSet("moving", 500, 500, rekt.NewRectangle("moving-1", 0, 0, 50, 50))
+	others := []*rekt.Set[string]{anchor, neighbour, moving}
+
+	for _, testCase := range snapSetTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			snap := rekt.SnapSet(moving, testCase.x, testCase.y, others, testCase.threshold)
+
+			require.Equal(t, testCase.expectedX, snap.X)
+			require.Equal(t, testCase.expectedY, snap.Y)
+			require.Equal(t, 500, moving.X)
+			require.Equal(t, 500, moving.Y)
+
+			if testCase.target == "" {
+				require.False(t, snap.Snapped())
+				return
+			}
+
+			require.True(t, snap.Snapped())
+			require.Equal(t, testCase.target, snap.Target.ID)
+			require.Equal(t, testCase.edge, snap.Edge)
+			require.Equal(t, testCase.targetEdge, snap.TargetEdge)
+
+			snapped := moving.OffsetChildren()[0].Offset(rekt.NewRectangle("", snap.X-moving.X, snap.Y-moving.Y, 0, 0))
+			target := snap.Target.OffsetChildren()[0]
+			require.Contains(t, snapped.Touches(target), snap.Edge)
+			require.NotNil(t, snapped.TouchCoordinates(target, snap.Edge))
+			require.False(t, snapped.Overlaps(target))
+		})
+	}
+}
+
+func TestSnapSetIgnoresOverlappingSnaps(t *testing.T) {
+	anchor := layoutSet("anchor", 0, 0, rekt.NewRectangle("anchor-1", 0, 0, 100, 100))
+	blocker := layoutSet("blocker", 100, 0, rekt.NewRectangle("blocker-1", 0, 0, 10, 10))
+	moving := layoutSet("moving", 500, 500, rekt.NewRectangle("moving-1", 0, 0, 50, 50))
+
+	// snapping to the anchor is closer but would place the set on top of the blocker
+	snap := rekt.SnapSet(moving, 104, 5, []*rekt.Set[string]{anchor, blocker}, 10)
+
+	require.True(t, snap.Snapped())
+	require.Equal(t, "blocker", snap.Target.ID)
+	require.Equal(t, rekt.Top, snap.Edge)
+	require.Equal(t, 104, snap.X)
+	require.Equal(t, 10, snap.Y)
+}
+
+func TestLayoutSnapSet(t *testing.T) {
+	anchor := layoutSet("anchor", 0, 0, rekt.NewRectangle("anchor-1", 0, 0, 100, 100))
+	moving := layoutSet("moving", 500, 500, rekt.NewRectangle("moving-1", 0, 0, 50, 50))
+	layout, _ := rekt.NewLayout(anchor, moving)
+
+	snap := layout.SnapSet(moving, 95, 0, 10)
+	require.True(t, snap.Snapped())
+	require.Equal(t, 100, snap.X)
+	require.Nil(t, layout.MoveSet(moving, snap.X, snap.Y))
+}
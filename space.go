@@ -0,0 +1,153 @@
+package rekt
+
+// LocalRectangle is a Rectangle with coordinates relative to the space of the Set it belongs to
+//
+// the Rectangle is a named field rather than embedded so that none of its methods are promoted,
+// the comparison methods below only accept other local rectangles which stops local and world
+// coords being mixed by accident, the plain Rectangle is still available through the field when
+// that is really wanted
+type LocalRectangle[T any] struct {
+	Rectangle Rectangle[T]
+}
+
+// WorldRectangle is a Rectangle with coordinates relative to world space
+//
+// like LocalRectangle the comparison methods only accept other world rectangles
+//
+// both types are encoded in exactly the same way as a plain Rectangle
+type WorldRectangle[T any] struct {
+	Rectangle Rectangle[T]
+}
+
+// Overlaps checks if the reciever overlaps the target
+func (rect LocalRectangle[T]) Overlaps(target LocalRectangle[T]) bool {
+	return rect.Rectangle.Overlaps(target.Rectangle)
+}
+
+// OverlappingArea returns the area of the reciever that overlaps the target
+// nil will be returned if they do not overlap
+func (rect LocalRectangle[T]) OverlappingArea(target LocalRectangle[T]) *LocalRectangle[T] {
+	area := rect.Rectangle.OverlappingArea(target.Rectangle)
+	if area == nil {
+		return nil
+	}
+
+	return &LocalRectangle[T]{*area}
+}
+
+// Touches returns a slice of sides in which the reciever touches the target
+func (rect LocalRectangle[T]) Touches(target LocalRectangle[T]) []Edge {
+	return rect.Rectangle.Touches(target.Rectangle)
+}
+
+// TouchCoordinates returns the coordinates of the touching section of the given edge
+// nil will be returned if the edge is not touching
+func (rect LocalRectangle[T]) TouchCoordinates(target LocalRectangle[T], edge Edge) *EdgeCoordinates[T] {
+	return rect.Rectangle.TouchCoordinates(target.Rectangle, edge)
+}
+
+// Overlaps checks if the reciever overlaps the target
+func (rect WorldRectangle[T]) Overlaps(target WorldRectangle[T]) bool {
+	return rect.Rectangle.Overlaps(target.Rectangle)
+}
+
+// OverlappingArea returns the area of the reciever that overlaps the target
+// nil will be returned if they do not overlap
+func (rect WorldRectangle[T]) OverlappingArea(target WorldRectangle[T]) *WorldRectangle[T] {
+	area := rect.Rectangle.OverlappingArea(target.Rectangle)
+	if area == nil {
+		return nil
+	}
+
+	return &WorldRectangle[T]{*area}
+}
+
+// Touches returns a slice of sides in which the reciever touches the target
+func (rect WorldRectangle[T]) Touches(target WorldRectangle[T]) []Edge {
+	return rect.Rectangle.Touches(target.Rectangle)
+}
+
+// TouchCoordinates returns the coordinates of the touching section of the given edge
+// nil will be returned if the edge is not touching
+func (rect WorldRectangle[T]) TouchCoordinates(target WorldRectangle[T], edge Edge) *EdgeCoordinates[T] {
+	return rect.Rectangle.TouchCoordinates(target.Rectangle, edge)
+}
+
+// WorldRect returns the embedded Rectangle of the set typed as a world space rectangle
+// see the Set docs for how this differs from WorldBounds
+func (set *Set[T]) WorldRect() WorldRectangle[T] {
+	return WorldRectangle[T]{set.Rectangle}
+}
+
+// ToWorld converts a rectangle in the Set space into world space
+func (set *Set[T]) ToWorld(rect LocalRectangle[T]) WorldRectangle[T] {
+	return WorldRectangle[T]{rect.Rectangle.Offset(set.Rectangle)}
+}
+
+// ToLocal converts a world space rectangle into the Set space
+func (set *Set[T]) ToLocal(rect WorldRectangle[T]) LocalRectangle[T] {
+	return LocalRectangle[T]{set.localise(rect.Rectangle)}
+}
+
+// LocalChildren returns a copy of the children of the set typed as Set space rectangles
+func (set *Set[T]) LocalChildren() []LocalRectangle[T] {
+	children := make([]LocalRectangle[T], 0, len(set.children))
+
+	for _, rect := range set.children {
+		children = append(children, LocalRectangle[T]{rect})
+	}
+
+	return children
+}
+
+// WorldChildren returns a copy of the children of the set typed as world space rectangles
+// this is the typed equivalent of OffsetChildren
+func (set *Set[T]) WorldChildren() []WorldRectangle[T] {
+	children := make([]WorldRectangle[T], 0, len(set.children))
+
+	for _, rect := range set.OffsetChildren() {
+		children = append(children, WorldRectangle[T]{rect})
+	}
+
+	return children
+}
+
+// OverlappingWorld returns the children of the set that overlap the world space area
+// Coordinates of the returned children will be relative to world space
+func (set *Set[T]) OverlappingWorld(area WorldRectangle[T]) []WorldRectangle[T] {
+	var overlapping []WorldRectangle[T]
+
+	for _, i := range set.overlapping(set.localise(area.Rectangle)) {
+		overlapping = append(overlapping, WorldRectangle[T]{set.children[i].Offset(set.Rectangle)})
+	}
+
+	return overlapping
+}
+
+// TouchingWorld returns the children of the set that touch an edge of the world space rectangle
+// Coordinates of the returned children will be relative to world space
+func (set *Set[T]) TouchingWorld(target WorldRectangle[T]) []WorldRectangle[T] {
+	var touching []WorldRectangle[T]
+
+	for _, i := range set.touching(set.localise(target.Rectangle)) {
+		touching = append(touching, WorldRectangle[T]{set.children[i].Offset(set.Rectangle)})
+	}
+
+	return touching
+}
+
+// OverlapsWorldChildren returns the children of the target Set that overlap the bounding box of
+// the recievers Set
+// unlike OverlapsChildren both sets are compared in world space so the result is correct
+// wherever the sets are positioned, the returned children are also in world space
+func (set *Set[T]) OverlapsWorldChildren(target *Set[T]) []WorldRectangle[T] {
+	return target.OverlappingWorld(set.WorldRect())
+}
+
+// TouchesWorldChildren returns the children of the target Set that touch an edge of the bounding
+// box of the recievers Set
+// unlike TouchesChildren both sets are compared in world space so the result is correct
+// wherever the sets are positioned, the returned children are also in world space
+func (set *Set[T]) TouchesWorldChildren(target *Set[T]) []WorldRectangle[T] {
+	return target.TouchingWorld(set.WorldRect())
+}
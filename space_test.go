@@ -0,0 +1,91 @@
+package rekt_test
+
+import (
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+func world(id string, x, y, w, z int) rekt.WorldRectangle[string] {
+	return rekt.WorldRectangle[string]{Rectangle: rekt.NewRectangle(id, x, y, w, z)}
+}
+
+func worldIDs(rects []rekt.WorldRectangle[string]) []string {
+	var ids []string
+	for _, rect := range rects {
+		ids = append(ids, rect.Rectangle.ID)
+	}
+
+	return ids
+}
+
+func TestSetWorldConversion(t *testing.T) {
+	set := layoutSet("set", 100, 50, rekt.NewRectangle("set-1", 10, 10, 20, 20))
+
+	local := set.LocalChildren()[0]
+	converted := set.ToWorld(local)
+
+	require.Equal(t, world("set-1", 110, 60, 120, 70), converted)
+	require.Equal(t, set.WorldChildren()[0], converted)
+	require.Equal(t, set.OffsetChildren()[0], converted.Rectangle)
+	require.Equal(t, local, set.ToLocal(converted))
+	require.Equal(t, world("set", 100, 50, 120, 70), set.WorldRect())
+}
+
+func TestWorldRectangleComparisons(t *testing.T) {
+	a := world("a", 0, 0, 100, 100)
+	b := world("b", 100, 0, 200, 100)
+	c := world("c", 50, 50, 150, 150)
+
+	require.False(t, a.Overlaps(b))
+	require.True(t, a.Overlaps(c))
+	require.Equal(t, &rekt.WorldRectangle[string]{Rectangle: rekt.NewRectangle("c", 50, 50, 100, 100)}, a.OverlappingArea(c))
+	require.Nil(t, a.OverlappingArea(b))
+	require.Equal(t, []rekt.Edge{rekt.Right}, a.Touches(b))
+
+	gapped := world("gapped", 102, 0, 200, 100)
+	require.Nil(t, a.Touches(gapped))
+	require.Equal(t, []rekt.Edge{rekt.Right}, a.TouchesWithin(gapped, 2))
+
+	coords, gap := a.TouchCoordinatesWithin(gapped, rekt.Right, 2)
+	require.Equal(t, &rekt.EdgeCoordinates[string]{ID: "gapped", X: 100, Y: 0, W: 100, Z: 100}, coords)
+	require.Equal(t, 2, gap)
+	require.Equal(t, &rekt.EdgeCoordinates[string]{ID: "b", X: 100, Y: 0, W: 100, Z: 100}, a.TouchCoordinates(b, rekt.Right))
+}
+
+func TestSetOverlapsWorldChildren(t *testing.T) {
+	// a second machine positioned to the right of the first
+	first := layoutSet("first", 0, 0,
+		rekt.NewRectangle("first-1", 0, 0, 100, 100),
+		rekt.NewRectangle("first-2", 100, 0, 200, 50),
+	)
+	second := layoutSet("second", 200, 0,
+		rekt.NewRectangle("second-1", 0, 0, 100, 100),
+		rekt.NewRectangle("second-2", 0, 100, 100, 200),
+	)
+
+	// the local version compares first against seconds children as if second was at 0,0
+	require.Equal(t, []rekt.Rectangle[string]{rekt.NewRectangle("second-1", 0, 0, 100, 100)}, first.OverlapsChildren(*second))
+	require.Nil(t, first.OverlapsWorldChildren(second))
+
+	second.Move(150, 50)
+	require.Equal(t, []rekt.WorldRectangle[string]{world("second-1", 150, 50, 250, 150)}, first.OverlapsWorldChildren(second))
+
+	second.EnableIndex(0)
+	require.Equal(t, []rekt.WorldRectangle[string]{world("second-1", 150, 50, 250, 150)}, first.OverlapsWorldChildren(second))
+}
+
+func TestSetTouchesWorldChildren(t *testing.T) {
+	first := layoutSet("first", 0, 0, rekt.NewRectangle("first-1", 0, 0, 100, 100))
+	second := layoutSet("second", 100, 20,
+		rekt.NewRectangle("second-1", 0, 0, 100, 100),
+		rekt.NewRectangle("second-2", 100, 0, 200, 100),
+	)
+
+	require.Equal(t, []rekt.WorldRectangle[string]{world("second-1", 100, 20, 200, 120)}, first.TouchesWorldChildren(second))
+	require.Equal(t, []rekt.WorldRectangle[string]{world("first-1", 0, 0, 100, 100)}, second.TouchesWorldChildren(first))
+
+	second.Move(300, 0)
+	require.Nil(t, first.TouchesWorldChildren(second))
+}
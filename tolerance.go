@@ -0,0 +1,208 @@
+package rekt
+
+// TouchesWithin works the same as Touches but treats edges that are up to tolerance units apart
+// as touching
+// this allows for displays that are reported slightly out of alignment or have deliberate gaps
+// between them, a tolerance of 0 behaves exactly like Touches
+//
+// only gaps are tolerated, rectangles that overlap across an edge are never treated as touching
+// on that edge no matter how small the overlap
+func (rect Rectangle[T]) TouchesWithin(target Rectangle[T], tolerance int) []Edge {
+	var edges []Edge
+
+	for _, edge := range []Edge{Top, Right, Bottom, Left} {
+		if _, ok := touchGap(rect, target, edge, tolerance); ok {
+			edges = append(edges, edge)
+		}
+	}
+
+	return edges
+}
+
+// TouchCoordinatesWithin works the same as TouchCoordinates but treats edges that are up to
+// tolerance units apart as touching
+// the returned coordinates are placed on the edge of the reciever and the actual distance
+// between the two edges is returned along side them
+// if there is no touch on the given edge nil will be returned
+func (rect Rectangle[T]) TouchCoordinatesWithin(target Rectangle[T], edge Edge, tolerance int) (*EdgeCoordinates[T], int) {
+	gap, ok := touchGap(rect, target, edge, tolerance)
+	if !ok {
+		return nil, 0
+	}
+
+	return touchLine(rect, target, edge), gap
+}
+
+// TouchesWithin works the same as Touches but treats edges that are up to tolerance units apart
+// as touching, see Rectangle.TouchesWithin
+func (rect LocalRectangle[T]) TouchesWithin(target LocalRectangle[T], tolerance int) []Edge {
+	return rect.Rectangle.TouchesWithin(target.Rectangle, tolerance)
+}
+
+// TouchCoordinatesWithin works the same as TouchCoordinates but treats edges that are up to
+// tolerance units apart as touching, see Rectangle.TouchCoordinatesWithin
+func (rect LocalRectangle[T]) TouchCoordinatesWithin(target LocalRectangle[T], edge Edge, tolerance int) (*EdgeCoordinates[T], int) {
+	return rect.Rectangle.TouchCoordinatesWithin(target.Rectangle, edge, tolerance)
+}
+
+// TouchesWithin works the same as Touches but treats edges that are up to tolerance units apart
+// as touching, see Rectangle.TouchesWithin
+func (rect WorldRectangle[T]) TouchesWithin(target WorldRectangle[T], tolerance int) []Edge {
+	return rect.Rectangle.TouchesWithin(target.Rectangle, tolerance)
+}
+
+// TouchCoordinatesWithin works the same as TouchCoordinates but treats edges that are up to
+// tolerance units apart as touching, see Rectangle.TouchCoordinatesWithin
+func (rect WorldRectangle[T]) TouchCoordinatesWithin(target WorldRectangle[T], edge Edge, tolerance int) (*EdgeCoordinates[T], int) {
+	return rect.Rectangle.TouchCoordinatesWithin(target.Rectangle, edge, tolerance)
+}
+
+// neighbourCoordinatesWithin works the same as neighbourCoordinates but treats a target that is
+// up to tolerance units away on the outside of the edge as a neighbour
+// the distance between the edges is returned along side the coordinates
+func (rect Rectangle[T]) neighbourCoordinatesWithin(target Rectangle[T], edge Edge, tolerance int) (*EdgeCoordinates[T], int) {
+	gap, ok := facingGap(rect, target, edge)
+	if !ok || gap < 0 || gap > tolerance || !sharesSpan(rect, target, edge) {
+		return nil, 0
+	}
+
+	return touchLine(rect, target, edge), gap
+}
+
+// touchGap finds the distance between the edge of rect and the facing edge of target
+// an edge of target that lines up exactly with the edge of rect is also a touch (see Touches) with
+// a gap of 0
+// false will be returned if target overlaps rect across the edge, the gap is more than tolerance
+// or the two rectangles do not share any length along the edge
+func touchGap[T any](rect, target Rectangle[T], edge Edge, tolerance int) (int, bool) {
+	gap, ok := facingGap(rect, target, edge)
+	if !ok {
+		return 0, false
+	}
+
+	var aligned bool
+	switch edge {
+	case Top:
+		aligned = rect.Y == target.Y
+	case Right:
+		aligned = rect.W == target.W
+	case Bottom:
+		aligned = rect.Z == target.Z
+	case Left:
+		aligned = rect.X == target.X
+	}
+
+	if aligned {
+		gap = 0
+	} else if gap < 0 || gap > tolerance {
+		return 0, false
+	}
+
+	if !sharesSpan(rect, target, edge) {
+		return 0, false
+	}
+
+	return gap, true
+}
+
+// facingGap finds the signed distance from the edge of rect out to the facing edge of target
+// a negative gap means that target overlaps rect across the edge
+// false will be returned if the edge is not valid
+func facingGap[T any](rect, target Rectangle[T], edge Edge) (int, bool) {
+	switch edge {
+	case Top:
+		return rect.Y - target.Z, true
+	case Right:
+		return target.X - rect.W, true
+	case Bottom:
+		return target.Y - rect.Z, true
+	case Left:
+		return rect.X - target.W, true
+	default:
+		return 0, false
+	}
+}
+
+// sharesSpan checks if the rectangles share any length along the axis of the edge
+func sharesSpan[T any](rect, target Rectangle[T], edge Edge) bool {
+	if edge == Top || edge == Bottom {
+		return rect.X < target.W && rect.W > target.X
+	}
+
+	return rect.Y < target.Z && rect.Z > target.Y
+}
+
+// touchLine builds the coordinates of the section of the edge of rect that is shared with target
+func touchLine[T any](rect, target Rectangle[T], edge Edge) *EdgeCoordinates[T] {
+	switch edge {
+	case Top, Bottom:
+		y := rect.Y
+		if edge == Bottom {
+			y = rect.Z
+		}
+
+		return &EdgeCoordinates[T]{
+			ID: target.ID,
+			X:  max(rect.X, target.X),
+			Y:  y,
+			W:  min(rect.W, target.W),
+			Z:  y,
+		}
+
+	default:
+		x := rect.X
+		if edge == Right {
+			x = rect.W
+		}
+
+		return &EdgeCoordinates[T]{
+			ID: target.ID,
+			X:  x,
+			Y:  max(rect.Y, target.Y),
+			W:  x,
+			Z:  min(rect.Z, target.Z),
+		}
+	}
+}
+
+// TouchesChildrenWithin works the same as TouchesChildren but treats edges that are up to
+// tolerance units apart as touching
+func (set *Set[T]) TouchesChildrenWithin(target Set[T], tolerance int) []Rectangle[T] {
+	var touching []Rectangle[T]
+
+	for _, i := range target.touchingWithin(set.Rectangle, tolerance) {
+		touching = append(touching, target.children[i])
+	}
+
+	return touching
+}
+
+// TouchesWorldChildrenWithin works the same as TouchesWorldChildren but treats edges that are up
+// to tolerance units apart as touching
+func (set *Set[T]) TouchesWorldChildrenWithin(target *Set[T], tolerance int) []WorldRectangle[T] {
+	return target.TouchingWorldWithin(set.WorldRect(), tolerance)
+}
+
+// TouchingWorldWithin works the same as TouchingWorld but treats edges that are up to tolerance
+// units apart as touching
+func (set *Set[T]) TouchingWorldWithin(target WorldRectangle[T], tolerance int) []WorldRectangle[T] {
+	var touching []WorldRectangle[T]
+
+	for _, i := range set.touchingWithin(set.localise(target.Rectangle), tolerance) {
+		touching = append(touching, WorldRectangle[T]{set.children[i].Offset(set.Rectangle)})
+	}
+
+	return touching
+}
+
+// NewWorldGraphWithin works the same as NewWorldGraph but links nodes that are up to tolerance
+// units apart, the distance between the nodes is recorded in the Gap of each Link
+func NewWorldGraphWithin[T comparable](tolerance int, sets ...*Set[T]) *Graph[T] {
+	var nodes []Node[T]
+
+	for _, owned := range worldContent(sets) {
+		nodes = append(nodes, Node[T]{Rectangle: owned.rect, Set: owned.set})
+	}
+
+	return newGraph(nodes, tolerance)
+}
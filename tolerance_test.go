@@ -0,0 +1,184 @@
+package rekt_test
+
+import (
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+var rectangleTouchesWithinTests = []struct {
+	name      string
+	rect      rekt.Rectangle[string]
+	target    rekt.Rectangle[string]
+	tolerance int
+	expected  []rekt.Edge
+}{
+	{"exact", rekt.NewRectangle("a", 0, 0, 100, 100), rekt.NewRectangle("b", 100, 0, 200, 100), 0, []rekt.Edge{rekt.Right}},
+	{"gap without tolerance", rekt.NewRectangle("a", 0, 0, 100, 100), rekt.NewRectangle("b", 101, 0, 200, 100), 0, nil},
+	{"gap within tolerance", rekt.NewRectangle("a", 0, 0, 100, 100), rekt.NewRectangle("b", 101, 0, 200, 100), 1, []rekt.Edge{rekt.Right}},
+	{"gap outside tolerance", rekt.NewRectangle("a", 0, 0, 100, 100), rekt.NewRectangle("b", 110, 0, 200, 100), 5, nil},
+	{"overlap is not a gap", rekt.NewRectangle("a", 0, 0, 100, 100), rekt.NewRectangle("b", 10, 98, 110, 200), 2, nil},
+	{"overlap is not a gap without tolerance", rekt.NewRectangle("a", 0, 0, 100, 100), rekt.NewRectangle("b", 10, 99, 110, 200), 0, nil},
+	{"no shared span", rekt.NewRectangle("a", 0, 0, 100, 100), rekt.NewRectangle("b", 101, 100, 200, 200), 5, nil},
+	{"above", rekt.NewRectangle("a", 0, 100, 100, 200), rekt.NewRectangle("b", 50, 0, 150, 97), 3, []rekt.Edge{rekt.Top}},
+	{"left", rekt.NewRectangle("a", 100, 0, 200, 100), rekt.NewRectangle("b", 0, 50, 99, 150), 1, []rekt.Edge{rekt.Left}},
+}
+
+func TestRectangleTouchesWithin(t *testing.T) {
+	for _, testCase := range rectangleTouchesWithinTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, testCase.rect.TouchesWithin(testCase.target, testCase.tolerance))
+
+			if testCase.tolerance == 0 {
+				require.Equal(t, testCase.rect.Touches(testCase.target), testCase.rect.TouchesWithin(testCase.target, 0))
+			}
+		})
+	}
+}
+
+func TestRectangleTouchCoordinatesWithin(t *testing.T) {
+	a := rekt.NewRectangle("a", 0, 0, 100, 100)
+	b := rekt.NewRectangle("b", 102, 50, 200, 150)
+
+	coords, gap := a.TouchCoordinatesWithin(b, rekt.Right, 2)
+	require.Equal(t, &rekt.EdgeCoordinates[string]{ID: "b", X: 100, Y: 50, W: 100, Z: 100}, coords)
+	require.Equal(t, 2, gap)
+
+	coords, gap = a.TouchCoordinatesWithin(b, rekt.Right, 1)
+	require.Nil(t, coords)
+	require.Equal(t, 0, gap)
+
+	// with no gap the coordinates match TouchCoordinates
+	c := rekt.NewRectangle("c", 100, 50, 200, 150)
+	coords, gap = a.TouchCoordinatesWithin(c, rekt.Right, 2)
+	require.Equal(t, a.TouchCoordinates(c, rekt.Right), coords)
+	require.Equal(t, 0, gap)
+}
+
+func TestSetTouchesWorldChildrenWithin(t *testing.T) {
+	first := layoutSet("first", 0, 0, rekt.NewRectangle("first-1", 0, 0, 1920, 1080))
+	// the second machine reports its display a pixel away from the first
+	second := layoutSet("second", 1921, 0,
+		rekt.NewRectangle("second-1", 0, 0, 1920, 1080),
+		rekt.NewRectangle("second-2", 1920, 0, 3840, 1080),
+	)
+
+	require.Nil(t, first.TouchesWorldChildren(second))
+	require.Equal(t, []rekt.WorldRectangle[string]{world("second-1", 1921, 0, 3841, 1080)}, first.TouchesWorldChildrenWithin(second, 1))
+
+	second.EnableIndex(0)
+	require.Equal(t, []rekt.WorldRectangle[string]{world("second-1", 1921, 0, 3841, 1080)}, first.TouchesWorldChildrenWithin(second, 1))
+	require.Nil(t, first.TouchesWorldChildrenWithin(second, 0))
+}
+
+func TestSetTouchesChildrenWithin(t *testing.T) {
+	set := layoutSet("set", 0, 0, rekt.NewRectangle("set-1", 0, 0, 100, 100))
+	target := layoutSet("target", 0, 0,
+		rekt.NewRectangle("target-1", 102, 0, 200, 100),
+		rekt.NewRectangle("target-2", 300, 0, 400, 100),
+	)
+
+	require.Nil(t, set.TouchesChildren(*target))
+	require.Equal(t, []rekt.Rectangle[string]{rekt.NewRectangle("target-1", 102, 0, 200, 100)}, set.TouchesChildrenWithin(*target, 2))
+}
+
+func TestGridTouchingWithin(t *testing.T) {
+	rects := []rekt.Rectangle[string]{
+		rekt.NewRectangle("left", 0, 0, 97, 100),
+		rekt.NewRectangle("right", 203, 0, 300, 100),
+		rekt.NewRectangle("far", 400, 0, 500, 100),
+	}
+	grid := rekt.NewGrid(10, rects)
+	target := rekt.NewRectangle("target", 100, 0, 200, 100)
+
+	require.Nil(t, grid.Touching(target))
+	require.Equal(t, []int{0, 1}, grid.TouchingWithin(target, 3))
+	require.Equal(t, []int(nil), grid.TouchingWithin(target, 2))
+}
+
+func TestNewWorldGraphWithin(t *testing.T) {
+	a := layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100))
+	b := layoutSet("b", 101, 0, rekt.NewRectangle("b-1", 0, 0, 100, 100))
+
+	// without a tolerance the 1px gap splits the displays
+	require.Len(t, rekt.NewWorldGraph(a, b).Components(), 2)
+
+	graph := rekt.NewWorldGraphWithin(1, a, b)
+	require.Len(t, graph.Components(), 1)
+	require.Equal(t, rekt.Link[string]{
+		To:          1,
+		ID:          "b-1",
+		Edge:        rekt.Right,
+		Coordinates: rekt.EdgeCoordinates[string]{ID: "b-1", X: 100, Y: 0, W: 100, Z: 100},
+		Gap:         1,
+	}, graph.Nodes[0].Links[rekt.Right][0])
+	require.Equal(t, 1, graph.Nodes[1].Links[rekt.Left][0].Gap)
+}
+
+func TestValidateConnectivityWithin(t *testing.T) {
+	a := layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100))
+	b := layoutSet("b", 101, 0, rekt.NewRectangle("b-1", 0, 0, 100, 100))
+	c := layoutSet("c", 0, 103, rekt.NewRectangle("c-1", 0, 0, 100, 100))
+
+	require.ErrorIs(t, rekt.ValidateConnectivity(a, b), rekt.ErrDisconnected)
+	require.Nil(t, rekt.ValidateConnectivityWithin(1, a, b))
+	require.ErrorIs(t, rekt.ValidateConnectivityWithin(1, a, b, c), rekt.ErrDisconnected)
+	require.Nil(t, rekt.ValidateConnectivityWithin(3, a, b, c))
+}
+
+var resolveCrossingWithinTests = []struct {
+	name      string
+	target    rekt.Rectangle[string]
+	from      rekt.Point
+	policy    rekt.WarpPolicy
+	tolerance int
+	// expected is nil when the cursor should be blocked
+	expected *rekt.Point
+}{
+	{"gap without tolerance", rekt.NewRectangle("b-1", 101, 0, 201, 100), rekt.NewPoint(95, 50), rekt.WarpBlock, 0, nil},
+	{"gap within tolerance", rekt.NewRectangle("b-1", 101, 0, 201, 100), rekt.NewPoint(95, 50), rekt.WarpBlock, 1, &rekt.Point{X: 106, Y: 50}},
+	{"gap outside tolerance", rekt.NewRectangle("b-1", 103, 0, 203, 100), rekt.NewPoint(95, 50), rekt.WarpBlock, 2, nil},
+	{"warp clamp without tolerance", rekt.NewRectangle("b-1", 101, 50, 201, 150), rekt.NewPoint(95, 20), rekt.WarpClamp, 0, nil},
+	{"warp clamp within tolerance", rekt.NewRectangle("b-1", 101, 50, 201, 150), rekt.NewPoint(95, 20), rekt.WarpClamp, 1, &rekt.Point{X: 106, Y: 50}},
+}
+
+func TestResolveCrossingWithin(t *testing.T) {
+	for _, testCase := range resolveCrossingWithinTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			sets := []*rekt.Set[string]{
+				layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100)),
+				layoutSet("b", 0, 0, testCase.target),
+			}
+
+			crossing := rekt.ResolveCrossingWithin(sets, testCase.from, 10, 0, rekt.MapAbsolute, testCase.policy, testCase.tolerance)
+			if testCase.expected == nil {
+				require.Nil(t, crossing)
+				return
+			}
+
+			require.NotNil(t, crossing)
+			require.Equal(t, "b-1", crossing.Child.ID)
+			require.Equal(t, *testCase.expected, crossing.Point)
+		})
+	}
+}
+
+func TestLayoutTolerance(t *testing.T) {
+	a := layoutSet("a", 0, 0, rekt.NewRectangle("a-1", 0, 0, 100, 100))
+	b := layoutSet("b", 101, 0, rekt.NewRectangle("b-1", 0, 0, 100, 100))
+	layout, _ := rekt.NewLayout(a, b)
+
+	require.Equal(t, 0, layout.Tolerance())
+	require.ErrorIs(t, layout.ValidateConnectivity(), rekt.ErrDisconnected)
+	require.Nil(t, layout.ResolveCrossing(rekt.NewPoint(95, 50), 10, 0, rekt.MapAbsolute))
+
+	layout.SetTolerance(1)
+	require.Equal(t, 1, layout.Tolerance())
+	require.Nil(t, layout.ValidateConnectivity())
+	require.Len(t, layout.Graph().Components(), 1)
+
+	crossing := layout.ResolveCrossingWith(rekt.NewPoint(95, 50), 10, 0, rekt.MapAbsolute, rekt.WarpBlock)
+	require.NotNil(t, crossing)
+	require.Equal(t, rekt.NewPoint(106, 50), crossing.Point)
+}
@@ -0,0 +1,76 @@
+package rekt
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ValidationError describes a single problem found when validating a Rectangle
+// it wraps the matching sentinel error (ErrZoroArea, ErrBadPoints etc) so can be checked with
+// errors.Is
+//
+// when more than one problem is found the ValidationErrors are combined with errors.Join, use
+// errors.As to get at the first or unwrap the joined error to get at all of them
+type ValidationError[T any] struct {
+	// ID is the id of the rectangle that failed validation
+	ID T
+	// Fields holds the names of the fields of the rectangle that caused the failure
+	Fields []string
+	// Reason describes the offending values
+	Reason string
+	// Err is the sentinel error for the failure
+	Err error
+}
+
+// Error implements error
+func (err *ValidationError[T]) Error() string {
+	return fmt.Sprintf("rectangle %v: %s (%s)", err.ID, err.Err, err.Reason)
+}
+
+// Unwrap allows errors.Is to match against the sentinel error
+func (err *ValidationError[T]) Unwrap() error {
+	return err.Err
+}
+
+var _ error = (*ValidationError[int])(nil)
+
+// newValidationError builds a ValidationError for the rectangle with the given id
+func newValidationError[T any](id T, err error, reason string, fields ...string) *ValidationError[T] {
+	return &ValidationError[T]{
+		ID:     id,
+		Fields: fields,
+		Reason: reason,
+		Err:    err,
+	}
+}
+
+// duplicateIDError describes a rectangle whose id is already in use within a set
+func duplicateIDError[T any](id T) error {
+	return newValidationError(id, ErrDuplicateID, "id is already in use", "ID")
+}
+
+// joinErrors combines the errors into one
+// nil is returned if there are no errors and a single error is returned as is
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errors.Join(errs...)
+	}
+}
+
+// splitErrors is the inverse of joinErrors, it returns the errors that make up a joined error
+func splitErrors(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return []error{err}
+}
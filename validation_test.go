@@ -0,0 +1,194 @@
+package rekt_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+// validationErrors unpacks the ValidationErrors that make up err
+func validationErrors[T any](t *testing.T, err error) []*rekt.ValidationError[T] {
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+
+	var validation []*rekt.ValidationError[T]
+	for _, err := range errs {
+		var target *rekt.ValidationError[T]
+		require.ErrorAs(t, err, &target)
+		validation = append(validation, target)
+	}
+
+	return validation
+}
+
+var validationErrorTests = []struct {
+	rect     rekt.Rectangle[string]
+	expected []rekt.ValidationError[string]
+}{
+	{
+		rekt.NewRectangle("zero width", 0, 0, 0, 10),
+		[]rekt.ValidationError[string]{
+			{ID: "zero width", Fields: []string{"X", "W"}, Reason: "width is 0", Err: rekt.ErrZoroArea},
+		},
+	},
+	{
+		rekt.NewRectangle("zero size", 10, 10, 10, 10),
+		[]rekt.ValidationError[string]{
+			{ID: "zero size", Fields: []string{"X", "W", "Y", "Z"}, Reason: "width is 0 and height is 0", Err: rekt.ErrZoroArea},
+		},
+	},
+	{
+		rekt.NewRectangle("flipped", 10, 0, 0, 10),
+		[]rekt.ValidationError[string]{
+			{ID: "flipped", Fields: []string{"X", "W"}, Reason: "X (10) is greater than W (0)", Err: rekt.ErrBadPoints},
+		},
+	},
+	{
+		rekt.NewRectangle("everything", 0, 10, 0, 0).
+			WithScale(-1).
+			WithPhysicalSize(-2, -3).
+			WithOrientation(rekt.Orientation{Rotation: 9}),
+		[]rekt.ValidationError[string]{
+			{ID: "everything", Fields: []string{"X", "W"}, Reason: "width is 0", Err: rekt.ErrZoroArea},
+			{ID: "everything", Fields: []string{"Y", "Z"}, Reason: "Y (10) is greater than Z (0)", Err: rekt.ErrBadPoints},
+			{ID: "everything", Fields: []string{"Scale"}, Reason: "Scale is -1", Err: rekt.ErrBadScale},
+			{ID: "everything", Fields: []string{"WidthMM"}, Reason: "WidthMM is -2", Err: rekt.ErrBadPhysicalSize},
+			{ID: "everything", Fields: []string{"HeightMM"}, Reason: "HeightMM is -3", Err: rekt.ErrBadPhysicalSize},
+			{ID: "everything", Fields: []string{"Orientation.Rotation"}, Reason: "Rotation is 9", Err: rekt.ErrBadRotation},
+		},
+	},
+}
+
+func TestValidationError(t *testing.T) {
+	for _, testCase := range validationErrorTests {
+		t.Run(testCase.rect.ID, func(t *testing.T) {
+			err := testCase.rect.Validate()
+			errs := validationErrors[string](t, err)
+
+			require.Len(t, errs, len(testCase.expected))
+			for i, expected := range testCase.expected {
+				require.Equal(t, expected, *errs[i])
+				require.ErrorIs(t, err, expected.Err)
+			}
+		})
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	err := rekt.NewRectangle("monitor-3", 10, 0, 0, 10).Validate()
+
+	require.EqualError(t, err, "rectangle monitor-3: rectangle has invalid coords (X (10) is greater than W (0))")
+}
+
+func TestValidateChildNegativePosition(t *testing.T) {
+	set, _ := rekt.NewSet("set", 0, 0, nil)
+
+	err := set.AddRectangle(rekt.NewRectangle("negative", -10, -5, -10, 10))
+	errs := validationErrors[string](t, err)
+
+	require.Len(t, errs, 2)
+	require.ErrorIs(t, err, rekt.ErrZoroArea)
+	require.ErrorIs(t, err, rekt.ErrNegativePositionInSet)
+	require.Equal(t, rekt.ValidationError[string]{
+		ID:     "negative",
+		Fields: []string{"X", "Y"},
+		Reason: "X is -10 and Y is -5",
+		Err:    rekt.ErrNegativePositionInSet,
+	}, *errs[1])
+}
+
+func TestNewSetReportsEveryChild(t *testing.T) {
+	set, err := rekt.NewSet("set", 0, 0, []rekt.Rectangle[string]{
+		rekt.NewRectangle("monitor-1", 0, 0, 100, 100),
+		rekt.NewRectangle("monitor-2", 100, 0, 100, 100),
+		rekt.NewRectangle("monitor-1", 200, 0, 300, 100),
+		rekt.NewRectangle("monitor-4", 300, 0, 400, 100).WithScale(-2),
+	})
+
+	require.Nil(t, set)
+	require.ErrorIs(t, err, rekt.ErrZoroArea)
+	require.ErrorIs(t, err, rekt.ErrDuplicateID)
+	require.ErrorIs(t, err, rekt.ErrBadScale)
+
+	var ids []string
+	for _, validation := range validationErrors[string](t, err) {
+		ids = append(ids, validation.ID)
+	}
+
+	require.Equal(t, []string{"monitor-2", "monitor-1", "monitor-4"}, ids)
+}
+
+func TestFloatValidationError(t *testing.T) {
+	err := rekt.NewFloatRectangle("float", math.NaN(), 0, 1, math.Inf(1)).Validate(rekt.DefaultEpsilon)
+	errs := validationErrors[string](t, err)
+
+	require.Len(t, errs, 2)
+	require.Equal(t, []string{"X"}, errs[0].Fields)
+	require.Equal(t, []string{"Z"}, errs[1].Fields)
+	require.True(t, errors.Is(err, rekt.ErrBadPoints))
+}
+
+func TestNestedSetNegativePosition(t *testing.T) {
+	root := layoutSet("root", 0, 0, rekt.NewRectangle("root-1", 0, 0, 100, 100))
+
+	errs := validationErrors[string](t, root.AddSet(layoutSet("negative", -1, -2)))
+	require.Equal(t, []*rekt.ValidationError[string]{{
+		ID:     "negative",
+		Fields: []string{"X", "Y"},
+		Reason: "X is -1 and Y is -2",
+		Err:    rekt.ErrNegativePositionInSet,
+	}}, errs)
+
+	require.Nil(t, root.AddSet(layoutSet("nested", 100, 0, rekt.NewRectangle("nested-1", 0, 0, 100, 100))))
+
+	errs = validationErrors[string](t, root.MoveSet("nested", 100, -5))
+	require.Equal(t, []*rekt.ValidationError[string]{{
+		ID:     "nested",
+		Fields: []string{"Y"},
+		Reason: "Y is -5",
+		Err:    rekt.ErrNegativePositionInSet,
+	}}, errs)
+}
+
+func TestLayoutSetOverlapsError(t *testing.T) {
+	left := layoutSet("left", 0, 0, rekt.NewRectangle("left-1", 0, 0, 100, 100))
+	right := layoutSet("right", 100, 0, rekt.NewRectangle("right-1", 0, 0, 100, 100))
+	layout, _ := rekt.NewLayout(left, right)
+
+	expected := []*rekt.ValidationError[string]{{
+		ID:     "overlapping",
+		Fields: []string{"X", "Y"},
+		Reason: "overlaps set left",
+		Err:    rekt.ErrSetOverlaps,
+	}}
+
+	overlapping := layoutSet("overlapping", 50, 50, rekt.NewRectangle("overlapping-1", 0, 0, 10, 10))
+	require.Equal(t, expected, validationErrors[string](t, layout.AddSet(overlapping)))
+
+	expected[0].ID = "right"
+	require.Equal(t, expected, validationErrors[string](t, layout.MoveSet(right, 50, 0)))
+}
+
+func TestNewFloatSetReportsEveryChild(t *testing.T) {
+	set, err := rekt.NewFloatSet("set", 0, 0, []rekt.FloatRectangle[string]{
+		rekt.NewFloatRectangle("monitor-1", 0, 0, 100, 100),
+		rekt.NewFloatRectangle("monitor-2", 100, 0, 100, 100),
+		rekt.NewFloatRectangle("monitor-3", -10, 0, 0, 100),
+	})
+
+	require.Nil(t, set)
+	require.ErrorIs(t, err, rekt.ErrZoroArea)
+	require.ErrorIs(t, err, rekt.ErrNegativePositionInSet)
+
+	var ids []string
+	for _, validation := range validationErrors[string](t, err) {
+		ids = append(ids, validation.ID)
+	}
+
+	require.Equal(t, []string{"monitor-2", "monitor-3"}, ids)
+}
@@ -0,0 +1,159 @@
+package rekt
+
+// WarpCandidate describes a Rectangle on the far side of the edge the cursor is leaving through
+// its position is given along the axis of that edge (Y for Left/Right, X for Top/Bottom)
+//
+// only Rectangle's that share some of the span of the edge are candidates, anything sitting off
+// to the side of the edge is never passed to a WarpPolicy
+type WarpCandidate struct {
+	// Start and End are the extent of the candidate along the edge, End is exclusive
+	Start int
+	End   int
+	// Gap is the distance from the edge being left to the edge of the candidate facing it
+	// this will be 0 for candidates that touch the edge, or are within the tolerance given to
+	// ResolveCrossingWithin
+	Gap int
+}
+
+// WarpPolicy decides where the cursor goes when it leaves a Rectangle at a point along an edge
+// that has no neighbour directly on the other side of it
+type WarpPolicy interface {
+	// Warp is given the extent of the edge being left (start..end, end exclusive), the position
+	// along it that the cursor left at and the candidates beyond the edge
+	//
+	// it returns the index of the candidate to move the cursor onto along with the position
+	// along the edge to enter it at, false will leave the cursor blocked at the edge
+	Warp(start, end, at int, candidates []WarpCandidate) (candidate int, position int, ok bool)
+}
+
+// WarpMode is the set of built in warp policies
+type WarpMode uint8
+
+const (
+	// WarpBlock stops the cursor at the edge
+	WarpBlock WarpMode = iota
+	// WarpClamp moves the cursor to the closest point along the edge that is shared with a
+	// touching neighbour
+	WarpClamp
+	// WarpProportional scales the position of the cursor along the full length of the edge onto
+	// the full length covered by the touching neighbours
+	WarpProportional
+	// WarpNearest jumps the cursor to the closest Rectangle beyond the edge, it does not need to
+	// be touching but it must share some of the span of the edge
+	WarpNearest
+)
+
+var _ WarpPolicy = WarpBlock
+
+// Warp implements WarpPolicy
+func (mode WarpMode) Warp(start, end, at int, candidates []WarpCandidate) (int, int, bool) {
+	switch mode {
+	case WarpClamp:
+		return warpClamp(start, end, at, candidates)
+	case WarpProportional:
+		return warpProportional(start, end, at, candidates)
+	case WarpNearest:
+		return warpNearest(at, candidates)
+	default:
+		return 0, 0, false
+	}
+}
+
+// warpClamp picks the touching candidate whose shared section of the edge is closest to at
+func warpClamp(start, end, at int, candidates []WarpCandidate) (int, int, bool) {
+	best, position, closest := -1, 0, 0
+
+	for i, candidate := range candidates {
+		if candidate.Gap != 0 {
+			continue
+		}
+
+		sharedStart, sharedEnd := max(start, candidate.Start), min(end, candidate.End)
+		if sharedStart >= sharedEnd {
+			continue
+		}
+
+		clamped := clamp(at, sharedStart, sharedEnd-1)
+		if best == -1 || abs(clamped-at) < closest {
+			best, position, closest = i, clamped, abs(clamped-at)
+		}
+	}
+
+	return best, position, best != -1
+}
+
+// warpProportional maps at from the edge being left onto the span covered by the touching
+// candidates
+func warpProportional(start, end, at int, candidates []WarpCandidate) (int, int, bool) {
+	var touching []WarpCandidate
+	var indexes []int
+
+	for i, candidate := range candidates {
+		if candidate.Gap == 0 {
+			touching = append(touching, candidate)
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(touching) == 0 || end <= start {
+		return 0, 0, false
+	}
+
+	spanStart, spanEnd := touching[0].Start, touching[0].End
+	for _, candidate := range touching[1:] {
+		spanStart = min(spanStart, candidate.Start)
+		spanEnd = max(spanEnd, candidate.End)
+	}
+
+	mapped := spanStart + (at-start)*(spanEnd-spanStart)/(end-start)
+
+	// the span may have gaps in it so fall back to the nearest touching candidate
+	i, position, _ := warpNearest(mapped, touching)
+
+	return indexes[i], position, true
+}
+
+// warpNearest picks the candidate closest to at, measured as the gap to the candidate plus the
+// distance along the edge
+func warpNearest(at int, candidates []WarpCandidate) (int, int, bool) {
+	best, position, closest := -1, 0, 0
+
+	for i, candidate := range candidates {
+		clamped := clamp(at, candidate.Start, candidate.End-1)
+		distance := candidate.Gap + abs(clamped-at)
+
+		if best == -1 || distance < closest {
+			best, position, closest = i, clamped, distance
+		}
+	}
+
+	return best, position, best != -1
+}
+
+// warpCandidate describes the rectangle as a WarpCandidate for the exit edge of source
+// false will be returned if the rectangle is not beyond the edge or does not share any of the span
+// of the edge
+func warpCandidate[T any](source, rect Rectangle[T], exit Edge) (WarpCandidate, bool) {
+	var gap int
+
+	switch exit {
+	case Top:
+		gap = source.Y - rect.Z
+	case Right:
+		gap = rect.X - source.W
+	case Bottom:
+		gap = rect.Y - source.Z
+	case Left:
+		gap = source.X - rect.W
+	}
+
+	if gap < 0 || !sharesSpan(source, rect, exit) {
+		return WarpCandidate{}, false
+	}
+
+	if exit == Left || exit == Right {
+		return WarpCandidate{Start: rect.Y, End: rect.Z, Gap: gap}, true
+	}
+
+	return WarpCandidate{Start: rect.X, End: rect.W, Gap: gap}, true
+}
@@ -0,0 +1,192 @@
+package rekt_test
+
+import (
+	"testing"
+
+	"github.com/indeedhat/rekt"
+	"github.com/stretchr/testify/require"
+)
+
+// warpSets builds a tall display with two short displays touching its right edge and a third
+// display sitting a short distance away
+func warpSets() []*rekt.Set[string] {
+	tall := layoutSet("tall", 0, 0, rekt.NewRectangle("tall-1", 0, 0, 100, 200))
+	short := layoutSet("short", 100, 0,
+		rekt.NewRectangle("short-1", 0, 0, 100, 50),
+		rekt.NewRectangle("short-2", 0, 100, 100, 150),
+	)
+	far := layoutSet("far", 110, 180, rekt.NewRectangle("far-1", 0, 0, 100, 120))
+
+	return []*rekt.Set[string]{tall, short, far}
+}
+
+var resolveCrossingWithTests = []struct {
+	name     string
+	from     rekt.Point
+	policy   rekt.WarpPolicy
+	expected *expectedCrossing
+}{
+	{"block", rekt.NewPoint(95, 75), rekt.WarpBlock, nil},
+	{
+		"clamp", rekt.NewPoint(95, 75), rekt.WarpClamp,
+		&expectedCrossing{"short", "short-2", rekt.Left, rekt.NewPoint(105, 100)},
+	},
+	{
+		"clamp ignores non touching", rekt.NewPoint(95, 190), rekt.WarpClamp,
+		&expectedCrossing{"short", "short-2", rekt.Left, rekt.NewPoint(105, 149)},
+	},
+	{
+		"proportional", rekt.NewPoint(95, 75), rekt.WarpProportional,
+		&expectedCrossing{"short", "short-1", rekt.Left, rekt.NewPoint(105, 49)},
+	},
+	{
+		"proportional within neighbour", rekt.NewPoint(95, 190), rekt.WarpProportional,
+		&expectedCrossing{"short", "short-2", rekt.Left, rekt.NewPoint(105, 142)},
+	},
+	{
+		"nearest touching", rekt.NewPoint(95, 75), rekt.WarpNearest,
+		&expectedCrossing{"short", "short-2", rekt.Left, rekt.NewPoint(105, 100)},
+	},
+	{
+		"nearest jumps gap", rekt.NewPoint(95, 190), rekt.WarpNearest,
+		&expectedCrossing{"far", "far-1", rekt.Left, rekt.NewPoint(115, 190)},
+	},
+	{
+		"direct neighbour ignores policy", rekt.NewPoint(95, 10), rekt.WarpBlock,
+		&expectedCrossing{"short", "short-1", rekt.Left, rekt.NewPoint(105, 10)},
+	},
+}
+
+func TestResolveCrossingWith(t *testing.T) {
+	sets := warpSets()
+
+	for _, testCase := range resolveCrossingWithTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			crossing := rekt.ResolveCrossingWith(sets, testCase.from, 10, 0, rekt.MapAbsolute, testCase.policy)
+			if testCase.expected == nil {
+				require.Nil(t, crossing)
+				return
+			}
+
+			require.NotNil(t, crossing)
+			require.Equal(t, testCase.expected.set, crossing.Set.ID)
+			require.Equal(t, testCase.expected.child, crossing.Child.ID)
+			require.Equal(t, testCase.expected.edge, crossing.Edge)
+			require.Equal(t, testCase.expected.point, crossing.Point)
+			require.True(t, crossing.Child.Contains(crossing.Point))
+		})
+	}
+}
+
+func TestResolveCrossingWithWarped(t *testing.T) {
+	sets := warpSets()
+
+	require.False(t, rekt.ResolveCrossingWith(sets, rekt.NewPoint(95, 10), 10, 0, rekt.MapAbsolute, rekt.WarpClamp).Warped)
+	require.True(t, rekt.ResolveCrossingWith(sets, rekt.NewPoint(95, 75), 10, 0, rekt.MapAbsolute, rekt.WarpClamp).Warped)
+
+	// nothing beyond the left edge so there is nothing to warp to
+	require.Nil(t, rekt.ResolveCrossingWith(sets, rekt.NewPoint(5, 75), -10, 0, rekt.MapAbsolute, rekt.WarpNearest))
+}
+
+var resolveCrossingWithOffAxisTests = []struct {
+	name     string
+	policy   rekt.WarpPolicy
+	beyond   bool
+	expected *expectedCrossing
+}{
+	{"nearest ignores off axis", rekt.WarpNearest, false, nil},
+	{"proportional ignores off axis", rekt.WarpProportional, false, nil},
+	{"clamp ignores off axis", rekt.WarpClamp, false, nil},
+	{
+		"nearest prefers in span over closer off axis", rekt.WarpNearest, true,
+		&expectedCrossing{"beyond", "beyond-1", rekt.Left, rekt.NewPoint(155, 50)},
+	},
+}
+
+func TestResolveCrossingWithOffAxis(t *testing.T) {
+	for _, testCase := range resolveCrossingWithOffAxisTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			sets := []*rekt.Set[string]{
+				layoutSet("source", 0, 0, rekt.NewRectangle("source-1", 0, 0, 100, 100)),
+				// touches the line of the right edge but sits well below it
+				layoutSet("off-axis", 100, 300, rekt.NewRectangle("off-axis-1", 0, 0, 100, 100)),
+			}
+			if testCase.beyond {
+				sets = append(sets, layoutSet("beyond", 150, 50, rekt.NewRectangle("beyond-1", 0, 0, 100, 100)))
+			}
+
+			crossing := rekt.ResolveCrossingWith(sets, rekt.NewPoint(95, 20), 10, 0, rekt.MapAbsolute, testCase.policy)
+			if testCase.expected == nil {
+				require.Nil(t, crossing)
+				return
+			}
+
+			require.NotNil(t, crossing)
+			require.Equal(t, testCase.expected.set, crossing.Set.ID)
+			require.Equal(t, testCase.expected.child, crossing.Child.ID)
+			require.Equal(t, testCase.expected.point, crossing.Point)
+		})
+	}
+}
+
+func TestResolveCrossingWithMapping(t *testing.T) {
+	sets := warpSets()
+
+	// clamp moves the cursor to the top of short-2, which is half way down the tall display so
+	// proportional mapping puts it half way down short-2
+	crossing := rekt.ResolveCrossingWith(sets, rekt.NewPoint(95, 75), 10, 0, rekt.MapProportional, rekt.WarpClamp)
+	require.NotNil(t, crossing)
+	require.Equal(t, "short-2", crossing.Child.ID)
+	require.Equal(t, rekt.NewPoint(105, 125), crossing.Point)
+
+	absolute := rekt.ResolveCrossingWith(sets, rekt.NewPoint(95, 75), 10, 0, rekt.MapAbsolute, rekt.WarpClamp)
+	require.Equal(t, rekt.NewPoint(105, 100), absolute.Point)
+}
+
+// lastCandidate is a custom policy that always warps to the start of the last candidate
+type lastCandidate struct{}
+
+func (lastCandidate) Warp(start, end, at int, candidates []rekt.WarpCandidate) (int, int, bool) {
+	last := len(candidates) - 1
+
+	return last, candidates[last].Start, true
+}
+
+func TestResolveCrossingWithCustomPolicy(t *testing.T) {
+	layout, _ := rekt.NewLayout(warpSets()...)
+
+	crossing := layout.ResolveCrossingWith(rekt.NewPoint(95, 75), 10, 0, rekt.MapAbsolute, lastCandidate{})
+	require.NotNil(t, crossing)
+	require.Equal(t, "far-1", crossing.Child.ID)
+	require.Equal(t, rekt.NewPoint(115, 180), crossing.Point)
+}
+
+var warpModeTests = []struct {
+	name       string
+	mode       rekt.WarpMode
+	candidates []rekt.WarpCandidate
+	candidate  int
+	position   int
+	ok         bool
+}{
+	{"block", rekt.WarpBlock, []rekt.WarpCandidate{{0, 50, 0}}, 0, 0, false},
+	{"clamp without touching", rekt.WarpClamp, []rekt.WarpCandidate{{0, 50, 5}}, -1, 0, false},
+	{"clamp outside of source edge", rekt.WarpClamp, []rekt.WarpCandidate{{200, 300, 0}}, -1, 0, false},
+	{"proportional without touching", rekt.WarpProportional, []rekt.WarpCandidate{{0, 50, 5}}, 0, 0, false},
+	{"nearest without candidates", rekt.WarpNearest, nil, -1, 0, false},
+	{"nearest prefers touching on tie", rekt.WarpNearest, []rekt.WarpCandidate{{0, 70, 0}, {75, 100, 6}}, 0, 69, true},
+}
+
+func TestWarpMode(t *testing.T) {
+	for _, testCase := range warpModeTests {
+		t.Run(testCase.name, func(t *testing.T) {
+			candidate, position, ok := testCase.mode.Warp(0, 200, 75, testCase.candidates)
+
+			require.Equal(t, testCase.ok, ok)
+			if ok {
+				require.Equal(t, testCase.candidate, candidate)
+				require.Equal(t, testCase.position, position)
+			}
+		})
+	}
+}